@@ -35,6 +35,7 @@ type DellCSIReplicationGroupStatus struct {
 	State                string               `json:"state,omitempty"`
 	RemoteState          string               `json:"remoteState,omitempty"`
 	ReplicationLinkState ReplicationLinkState `json:"replicationLinkState,omitempty"`
+	LinkOptions          LinkOptions          `json:"linkOptions,omitempty"`
 	LastAction           LastAction           `json:"lastAction,omitempty"`
 	Conditions           []LastAction         `json:"conditions,omitempty"`
 }
@@ -71,6 +72,23 @@ type ReplicationLinkState struct {
 	ErrorMessage string `json:"errorMessage,omitempty"`
 }
 
+// LinkOptions - Stores the effective options of the Replication Link
+type LinkOptions struct {
+	// Compression indicates if the replication traffic is compressed (enabled/disabled)
+	Compression string `json:"compression,omitempty"`
+
+	// Encryption indicates if the replication traffic is encrypted in flight (enabled/disabled)
+	Encryption string `json:"encryption,omitempty"`
+
+	// CompressionReportedByDriver indicates that the compression was confirmed by the driver in the protection group attributes.
+	// If false, the compression reflects the value requested through the storage class
+	CompressionReportedByDriver bool `json:"compressionReportedByDriver"`
+
+	// EncryptionReportedByDriver indicates that the encryption was confirmed by the driver in the protection group attributes.
+	// If false, the encryption reflects the value requested through the storage class
+	EncryptionReportedByDriver bool `json:"encryptionReportedByDriver"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster,shortName=rg
 // +kubebuilder:subresource:status
@@ -79,6 +97,8 @@ type ReplicationLinkState struct {
 // +kubebuilder:printcolumn:name="PG ID",type=string,JSONPath=`.spec.protectionGroupId`,description="Protection Group ID"
 // +kubebuilder:printcolumn:name="Link State",type=string,JSONPath=`.status.replicationLinkState.state`,description="Replication Link State"
 // +kubebuilder:printcolumn:name="Last LinkState Update",type=string,JSONPath=`.status.replicationLinkState.lastSuccessfulUpdate`,description="Replication Link State"
+// +kubebuilder:printcolumn:name="Encryption",type=string,JSONPath=`.status.linkOptions.encryption`,description="Replication Link Encryption",priority=1
+// +kubebuilder:printcolumn:name="Compression",type=string,JSONPath=`.status.linkOptions.compression`,description="Replication Link Compression",priority=1
 
 // DellCSIReplicationGroup is the Schema for the dellcsireplicationgroups API
 type DellCSIReplicationGroup struct {
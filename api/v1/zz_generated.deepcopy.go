@@ -210,6 +210,7 @@ func (in *DellCSIReplicationGroupSpec) DeepCopy() *DellCSIReplicationGroupSpec {
 func (in *DellCSIReplicationGroupStatus) DeepCopyInto(out *DellCSIReplicationGroupStatus) {
 	*out = *in
 	in.ReplicationLinkState.DeepCopyInto(&out.ReplicationLinkState)
+	out.LinkOptions = in.LinkOptions
 	in.LastAction.DeepCopyInto(&out.LastAction)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -260,6 +261,21 @@ func (in *LastAction) DeepCopy() *LastAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkOptions) DeepCopyInto(out *LinkOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkOptions.
+func (in *LinkOptions) DeepCopy() *LinkOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicationLinkState) DeepCopyInto(out *ReplicationLinkState) {
 	*out = *in
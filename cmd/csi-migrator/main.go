@@ -16,6 +16,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"log"
@@ -36,6 +37,7 @@ import (
 	storagev1 "github.com/dell/csm-replication/api/v1"
 	"github.com/dell/csm-replication/controllers"
 	"github.com/dell/csm-replication/pkg/common"
+	"github.com/dell/csm-replication/pkg/fips"
 
 	"golang.org/x/sync/singleflight"
 
@@ -102,9 +104,10 @@ func (mgr *MigratorManager) setupConfigMapWatcher(loggerConfig *logrus.Logger) {
 	})
 }
 
-func createMigratorManager(ctx context.Context, mgr ctrl.Manager) (*MigratorManager, error) {
+func createMigratorManager(ctx context.Context, mgr ctrl.Manager, fipsMode bool) (*MigratorManager, error) {
 	opts := config.GetControllerManagerOpts()
 	opts.Mode = "sidecar"
+	opts.FIPS = fipsMode
 	mgrLogger := mgr.GetLogger()
 	repConfig, err := config.GetConfig(ctx, nil, opts, nil, mgrLogger)
 	if err != nil {
@@ -136,6 +139,7 @@ func main() {
 		replicationDomain          string
 		probeFrequency             time.Duration
 		maxRetryDurationForActions time.Duration
+		fipsMode                   bool
 	)
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8001", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-election", false,
@@ -149,6 +153,7 @@ func main() {
 	flag.DurationVar(&retryIntervalMax, "retry-interval-max", 5*time.Minute, "Maximum retry interval of failed reconcile request")
 	flag.DurationVar(&operationTimeout, "timeout", 300*time.Second, "Timeout of waiting for response for CSI Driver")
 	flag.DurationVar(&probeFrequency, "probe-frequency", 5*time.Second, "Time between identity ProbeController calls")
+	flag.BoolVar(&fipsMode, "fips", false, "Enforce FIPS validated crypto for all the TLS connections. Refuses to start if the binary is not built with GOEXPERIMENT=boringcrypto or if a connection is not using verified TLS. The metrics are served over HTTPS")
 	flag.Parse()
 	controllers.InitLabelsAndAnnotations(domain)
 	logrusLog := logrus.New()
@@ -162,6 +167,22 @@ func main() {
 	setupLog.V(1).Info("Prefix", "Domain", domain)
 	setupLog.V(1).Info(common.DellCSIMigrator, "Version", core.SemVer, "Commit ID", core.CommitSha32, "Commit SHA", core.CommitTime.Format(time.RFC1123))
 
+	restConfig := ctrl.GetConfigOrDie()
+	var tlsOpts []func(*tls.Config)
+	if fipsMode {
+		// Refuse to operate unless all the TLS connections use FIPS validated crypto
+		if err := fips.Verify(); err != nil {
+			setupLog.Error(err, "unable to enable FIPS mode")
+			os.Exit(1)
+		}
+		if err := fips.VerifyRESTConfig(controllers.Self, restConfig); err != nil {
+			setupLog.Error(err, "unable to enable FIPS mode")
+			os.Exit(1)
+		}
+		tlsOpts = append(tlsOpts, fips.ConfigureTLS)
+		setupLog.V(common.InfoLevel).Info("FIPS mode enabled")
+	}
+
 	ctx := context.Background()
 
 	// Connect to csi
@@ -196,12 +217,14 @@ func main() {
 		}
 	}
 	leaderElectionID := common.DellCSIMigrator + strings.ReplaceAll(driverName, ".", "-")
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsServer.Options{
-			BindAddress: metricsAddr,
+			BindAddress:   metricsAddr,
+			SecureServing: fipsMode,
+			TLSOpts:       tlsOpts,
 		},
-		WebhookServer:              webhook.NewServer(webhook.Options{Port: 8443}),
+		WebhookServer:              webhook.NewServer(webhook.Options{Port: 8443, TLSOpts: tlsOpts}),
 		LeaderElection:             enableLeaderElection,
 		LeaderElectionResourceLock: "leases",
 		LeaderElectionID:           leaderElectionID,
@@ -211,7 +234,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	MigratorMgr, err := createMigratorManager(ctx, mgr)
+	MigratorMgr, err := createMigratorManager(ctx, mgr, fipsMode)
 	if err != nil {
 		setupLog.Error(err, "failed to configure the migrator manager")
 		os.Exit(1)
@@ -228,6 +251,7 @@ func main() {
 	logrusLog.SetLevel(level)
 
 	expRateLimiter := workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](retryIntervalStart, retryIntervalMax)
+	migrationClient := csimigration.New(csiConn, ctrl.Log.WithName("migration-client"), operationTimeout)
 
 	if err = (&controller.PersistentVolumeReconciler{
 		Client:            mgr.GetClient(),
@@ -235,7 +259,7 @@ func main() {
 		Scheme:            mgr.GetScheme(),
 		EventRecorder:     mgr.GetEventRecorderFor(common.DellCSIReplicator),
 		DriverName:        driverName,
-		MigrationClient:   csimigration.New(csiConn, ctrl.Log.WithName("migration-client"), operationTimeout),
+		MigrationClient:   migrationClient,
 		ContextPrefix:     pgContextKeyPrefix,
 		SingleFlightGroup: singleflight.Group{},
 		Domain:            domain,
@@ -251,7 +275,7 @@ func main() {
 		Scheme:                     mgr.GetScheme(),
 		EventRecorder:              mgr.GetEventRecorderFor(common.DellCSIMigrator),
 		DriverName:                 driverName,
-		MigrationClient:            csimigration.New(csiConn, ctrl.Log.WithName("migration-client"), operationTimeout),
+		MigrationClient:            migrationClient,
 		MaxRetryDurationForActions: maxRetryDurationForActions,
 	}).SetupWithManager(mgr, expRateLimiter, workerThreads); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DellCSIMigrationGroup")
@@ -17,6 +17,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"log"
@@ -32,6 +33,7 @@ import (
 	"github.com/dell/csm-replication/pkg/common"
 	"github.com/dell/csm-replication/pkg/config"
 	csiidentity "github.com/dell/csm-replication/pkg/csi-clients/identity"
+	"github.com/dell/csm-replication/pkg/fips"
 	"github.com/dell/dell-csi-extensions/migration"
 	"github.com/fsnotify/fsnotify"
 	"github.com/sirupsen/logrus"
@@ -98,9 +100,10 @@ func (mgr *NodeRescanner) setupConfigMapWatcher(loggerConfig *logrus.Logger) {
 	})
 }
 
-func createNodeReScannerManager(_ context.Context, mgr ctrl.Manager) (*NodeRescanner, error) {
+func createNodeReScannerManager(_ context.Context, mgr ctrl.Manager, fipsMode bool) (*NodeRescanner, error) {
 	opts := config.GetControllerManagerOpts()
 	opts.Mode = "sidecar"
+	opts.FIPS = fipsMode
 	//mgrLogger := mgr.GetLogger()
 	//repConfig, err := config.GetConfig(ctx, nil, opts, nil, mgrLogger)
 	//if err != nil {
@@ -135,6 +138,7 @@ func main() {
 		replicationDomain          string
 		probeFrequency             time.Duration
 		maxRetryDurationForActions time.Duration
+		fipsMode                   bool
 	)
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8001", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-election", false,
@@ -148,6 +152,7 @@ func main() {
 	flag.DurationVar(&retryIntervalMax, "retry-interval-max", 5*time.Minute, "Maximum retry interval of failed reconcile request")
 	flag.DurationVar(&operationTimeout, "timeout", 300*time.Second, "Timeout of waiting for response for CSI Driver")
 	flag.DurationVar(&probeFrequency, "probe-frequency", 5*time.Second, "Time between identity ProbeController calls")
+	flag.BoolVar(&fipsMode, "fips", false, "Enforce FIPS validated crypto for all the TLS connections. Refuses to start if the binary is not built with GOEXPERIMENT=boringcrypto or if a connection is not using verified TLS. The metrics are served over HTTPS")
 	flag.Parse()
 	controllers.InitLabelsAndAnnotations(domain)
 	logrusLog := logrus.New()
@@ -161,6 +166,22 @@ func main() {
 	setupLog.V(1).Info("Prefix", "Domain", domain)
 	setupLog.V(1).Info(common.DellCSINodeReScanner, "Version", core.SemVer, "Commit ID", core.CommitSha32, "Commit SHA", core.CommitTime.Format(time.RFC1123))
 
+	restConfig := ctrl.GetConfigOrDie()
+	var tlsOpts []func(*tls.Config)
+	if fipsMode {
+		// Refuse to operate unless all the TLS connections use FIPS validated crypto
+		if err := fips.Verify(); err != nil {
+			setupLog.Error(err, "unable to enable FIPS mode")
+			os.Exit(1)
+		}
+		if err := fips.VerifyRESTConfig(controllers.Self, restConfig); err != nil {
+			setupLog.Error(err, "unable to enable FIPS mode")
+			os.Exit(1)
+		}
+		tlsOpts = append(tlsOpts, fips.ConfigureTLS)
+		setupLog.V(common.InfoLevel).Info("FIPS mode enabled")
+	}
+
 	ctx := context.Background()
 
 	// Connect to csi
@@ -195,12 +216,14 @@ func main() {
 		}
 	}
 	leaderElectionID := common.DellCSINodeReScanner + strings.ReplaceAll(driverName, ".", "-")
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsServer.Options{
-			BindAddress: metricsAddr,
+			BindAddress:   metricsAddr,
+			SecureServing: fipsMode,
+			TLSOpts:       tlsOpts,
 		},
-		WebhookServer:              webhook.NewServer(webhook.Options{Port: 8443}),
+		WebhookServer:              webhook.NewServer(webhook.Options{Port: 8443, TLSOpts: tlsOpts}),
 		LeaderElection:             enableLeaderElection,
 		LeaderElectionResourceLock: "leases",
 		LeaderElectionID:           leaderElectionID,
@@ -210,7 +233,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	rescanMgr, err := createNodeReScannerManager(ctx, mgr)
+	rescanMgr, err := createNodeReScannerManager(ctx, mgr, fipsMode)
 	if err != nil {
 		setupLog.Error(err, "failed to configure the node re-rescanner manager")
 		os.Exit(1)
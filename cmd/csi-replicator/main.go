@@ -16,9 +16,11 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -31,6 +33,8 @@ import (
 
 	"github.com/dell/csm-replication/controllers"
 	"github.com/dell/csm-replication/pkg/common"
+	"github.com/dell/csm-replication/pkg/events"
+	"github.com/dell/csm-replication/pkg/fips"
 
 	"golang.org/x/sync/singleflight"
 
@@ -51,6 +55,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	metricsServer "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -104,9 +109,10 @@ func (mgr *ReplicatorManager) setupConfigMapWatcher(loggerConfig *logrus.Logger)
 	})
 }
 
-func createReplicatorManager(ctx context.Context, mgr ctrl.Manager) (*ReplicatorManager, error) {
+func createReplicatorManager(ctx context.Context, mgr ctrl.Manager, fipsMode bool) (*ReplicatorManager, error) {
 	opts := config.GetControllerManagerOpts()
 	opts.Mode = "sidecar"
+	opts.FIPS = fipsMode
 	mgrLogger := mgr.GetLogger()
 	repConfig, err := config.GetConfig(ctx, nil, opts, nil, mgrLogger)
 	if err != nil {
@@ -138,6 +144,12 @@ func main() {
 		monitoringInterval         time.Duration
 		probeFrequency             time.Duration
 		maxRetryDurationForActions time.Duration
+		strict                     bool
+		cloudEventsSink            string
+		cloudEventsFilter          string
+		fipsMode                   bool
+		probeHTTPHosts             string
+		probeJobNamespaces         string
 	)
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8000", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-election", false,
@@ -154,6 +166,13 @@ func main() {
 	flag.DurationVar(&probeFrequency, "probe-frequency", 5*time.Second, "Time between identity ProbeController calls")
 	flag.DurationVar(&maxRetryDurationForActions, "max-retry-action-duration", controller.MaxRetryDurationForActions,
 		"Max duration after (since the first error encountered) which action won't be retried")
+	flag.StringVar(&cloudEventsSink, "cloudevents-sink", "", "URL of the sink the RG lifecycle CloudEvents are delivered to. CloudEvents are disabled if empty")
+	flag.StringVar(&cloudEventsFilter, "cloudevents-filter", "", "CESQL expression selecting the RG lifecycle CloudEvents delivered to the sink")
+	flag.BoolVar(&strict, "strict", false, "Report malformed replication annotations and storage class parameters as errors instead of falling back to the defaults")
+	flag.BoolVar(&fipsMode, "fips", false, "Enforce FIPS validated crypto for all the TLS connections. Refuses to start if the binary is not built with GOEXPERIMENT=boringcrypto or if a connection is not using verified TLS. The metrics are served over HTTPS")
+	flag.StringVar(&probeHTTPHosts, "probe-http-hosts", "", "Comma separated list of the hosts (host or host:port) the HTTP action probes can send GET requests to. "+
+		"HTTP action probes are disabled if empty, as anyone who can annotate an RG can configure its probes")
+	flag.StringVar(&probeJobNamespaces, "probe-job-namespaces", "", "Comma separated list of the namespaces the Job action probes can run in. Job action probes are disabled if empty")
 	flag.Parse()
 	controllers.InitLabelsAndAnnotations(domain)
 	logrusLog := logrus.New()
@@ -167,6 +186,30 @@ func main() {
 	setupLog.V(1).Info("Prefix", "Domain", domain)
 	setupLog.V(1).Info(common.DellCSIReplicator, "Version", core.SemVer, "Commit ID", core.CommitSha32, "Commit SHA", core.CommitTime.Format(time.RFC1123))
 
+	restConfig := ctrl.GetConfigOrDie()
+	var tlsOpts []func(*tls.Config)
+	httpProbeClient := http.DefaultClient
+	if fipsMode {
+		// Refuse to operate unless all the TLS connections use FIPS validated crypto
+		if err := fips.Verify(); err != nil {
+			setupLog.Error(err, "unable to enable FIPS mode")
+			os.Exit(1)
+		}
+		if err := fips.VerifyRESTConfig(controllers.Self, restConfig); err != nil {
+			setupLog.Error(err, "unable to enable FIPS mode")
+			os.Exit(1)
+		}
+		if cloudEventsSink != "" {
+			if err := fips.VerifyURL(cloudEventsSink); err != nil {
+				setupLog.Error(err, "unable to enable FIPS mode")
+				os.Exit(1)
+			}
+		}
+		tlsOpts = append(tlsOpts, fips.ConfigureTLS)
+		httpProbeClient = fips.NewHTTPClient()
+		setupLog.V(common.InfoLevel).Info("FIPS mode enabled")
+	}
+
 	ctx := context.Background()
 
 	// Connect to csi
@@ -203,12 +246,14 @@ func main() {
 	}
 
 	leaderElectionID := common.DellCSIReplicator + strings.ReplaceAll(driverName, ".", "-")
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsServer.Options{
-			BindAddress: metricsAddr,
+			BindAddress:   metricsAddr,
+			SecureServing: fipsMode,
+			TLSOpts:       tlsOpts,
 		},
-		WebhookServer:              webhook.NewServer(webhook.Options{Port: 9443}),
+		WebhookServer:              webhook.NewServer(webhook.Options{Port: 9443, TLSOpts: tlsOpts}),
 		LeaderElection:             enableLeaderElection,
 		LeaderElectionResourceLock: "leases",
 		LeaderElectionID:           leaderElectionID,
@@ -218,7 +263,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	controllerMgr, err := createReplicatorManager(ctx, mgr)
+	controllerMgr, err := createReplicatorManager(ctx, mgr, fipsMode)
 	if err != nil {
 		setupLog.Error(err, "failed to configure the controller manager")
 		os.Exit(1)
@@ -236,7 +281,7 @@ func main() {
 
 	// Get the kube-system content
 	var clusterUID string
-	ns, err := getClusterUID(ctx)
+	ns, err := getClusterUID(ctx, mgr.GetAPIReader())
 	if err != nil {
 		log.Println("getClusterUuid error: ", err.Error())
 	} else {
@@ -245,13 +290,32 @@ func main() {
 	}
 
 	expRateLimiter := workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](retryIntervalStart, retryIntervalMax)
+	// The controllers share the same replication client, and so its pending state: at most 50 protection group
+	// requests (status, action, deletion) are in flight across the controllers, and at most one per protection group.
+	// The monitoring loop has its own client, so that its status polls are not rejected as pending while an action runs
+	replicationClient := csireplication.New(csiConn, ctrl.Log.WithName("replication-client"), operationTimeout)
+	eventRecorder := mgr.GetEventRecorderFor(common.DellCSIReplicator)
+	var eventEmitter events.Emitter
+	if cloudEventsSink != "" {
+		emitter, err := events.NewCloudEventEmitter(ctrl.Log.WithName("cloudevents"), cloudEventsSink,
+			fmt.Sprintf("/%s/%s", common.DellCSIReplicator, clusterUID), cloudEventsFilter)
+		if err != nil {
+			setupLog.Error(err, "unable to create cloudevents emitter")
+			os.Exit(1)
+		}
+		if err := mgr.Add(emitter); err != nil {
+			setupLog.Error(err, "unable to add cloudevents emitter")
+			os.Exit(1)
+		}
+		eventEmitter = emitter
+	}
 	if err = (&controller.PersistentVolumeClaimReconciler{
 		Client:            mgr.GetClient(),
 		Log:               ctrl.Log.WithName("controllers").WithName("PersistentVolumeClaim"),
 		Scheme:            mgr.GetScheme(),
-		EventRecorder:     mgr.GetEventRecorderFor(common.DellCSIReplicator),
+		EventRecorder:     eventRecorder,
 		DriverName:        driverName,
-		ReplicationClient: csireplication.New(csiConn, ctrl.Log.WithName("replication-client"), operationTimeout),
+		ReplicationClient: replicationClient,
 		ContextPrefix:     pgContextKeyPrefix,
 		SingleFlightGroup: singleflight.Group{},
 		Domain:            domain,
@@ -264,13 +328,16 @@ func main() {
 		Client:            mgr.GetClient(),
 		Log:               ctrl.Log.WithName("controllers").WithName("PersistentVolume"),
 		Scheme:            mgr.GetScheme(),
-		EventRecorder:     mgr.GetEventRecorderFor(common.DellCSIReplicator),
+		EventRecorder:     eventRecorder,
 		DriverName:        driverName,
-		ReplicationClient: csireplication.New(csiConn, ctrl.Log.WithName("replication-client"), operationTimeout),
+		ReplicationClient: replicationClient,
 		ContextPrefix:     pgContextKeyPrefix,
 		SingleFlightGroup: singleflight.Group{},
 		Domain:            domain,
 		ClusterUID:        clusterUID,
+		Strict:            strict,
+		EventEmitter:      eventEmitter,
+		PoolPlacer:        &controller.PoolPlacer{},
 	}).SetupWithManager(ctx, mgr, expRateLimiter, workerThreads); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PersistentVolume")
 		os.Exit(1)
@@ -280,11 +347,17 @@ func main() {
 		Client:                     mgr.GetClient(),
 		Log:                        ctrl.Log.WithName("controllers").WithName("DellCSIReplicationGroup"),
 		Scheme:                     mgr.GetScheme(),
-		EventRecorder:              mgr.GetEventRecorderFor(common.DellCSIReplicator),
+		EventRecorder:              eventRecorder,
 		DriverName:                 driverName,
-		ReplicationClient:          csireplication.New(csiConn, ctrl.Log.WithName("replication-client"), operationTimeout),
+		ReplicationClient:          replicationClient,
 		SupportedActions:           supportedActions,
 		MaxRetryDurationForActions: maxRetryDurationForActions,
+		Strict:                     strict,
+		EventEmitter:               eventEmitter,
+		HTTPProbeClient:            httpProbeClient,
+		FIPS:                       fipsMode,
+		ProbeHTTPHosts:             splitList(probeHTTPHosts),
+		ProbeJobNamespaces:         splitList(probeJobNamespaces),
 	}).SetupWithManager(mgr, expRateLimiter, workerThreads); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DellCSIReplicationGroup")
 		os.Exit(1)
@@ -316,14 +389,20 @@ func main() {
 	}
 }
 
-func getClusterUID(ctx context.Context) (*v1.Namespace, error) {
-	client, err := connection.GetControllerClient(nil, scheme)
-	if err != nil {
-		return nil, err
+// splitList returns the non-empty items of a comma separated list
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
+}
 
+func getClusterUID(ctx context.Context, reader client.Reader) (*v1.Namespace, error) {
 	ns := new(v1.Namespace)
-	err = client.Get(ctx, types.NamespacedName{Name: controllers.KubeSystemNamespace}, ns)
+	err := reader.Get(ctx, types.NamespacedName{Name: controllers.KubeSystemNamespace}, ns)
 	if err != nil {
 		return nil, err
 	}
@@ -16,6 +16,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"log"
@@ -29,6 +30,8 @@ import (
 	"github.com/dell/csm-replication/pkg/common"
 	"github.com/dell/csm-replication/pkg/config"
 	"github.com/dell/csm-replication/pkg/connection"
+	"github.com/dell/csm-replication/pkg/events"
+	"github.com/dell/csm-replication/pkg/fips"
 	"github.com/fsnotify/fsnotify"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
@@ -125,9 +128,10 @@ func (mgr *ControllerManager) setupConfigMapWatcher(loggerConfig *logrus.Logger)
 	})
 }
 
-func createControllerManager(ctx context.Context, mgr ctrl.Manager) (*ControllerManager, error) {
+func createControllerManager(ctx context.Context, mgr ctrl.Manager, fipsMode bool) (*ControllerManager, error) {
 	opts := config.GetControllerManagerOpts()
 	opts.Mode = "controller"
+	opts.FIPS = fipsMode
 	// We need to create a new client as the informer caches have not started yet
 	client, err := connection.GetControllerClient(nil, scheme)
 	if err != nil {
@@ -157,10 +161,18 @@ func createControllerManager(ctx context.Context, mgr ctrl.Manager) (*Controller
 
 func main() {
 	var (
-		retryIntervalStart time.Duration
-		retryIntervalMax   time.Duration
-		workerThreads      int
-		domain             string
+		retryIntervalStart   time.Duration
+		retryIntervalMax     time.Duration
+		pvcRequeueMin        time.Duration
+		pvcRequeueMax        time.Duration
+		rgRepairInterval     time.Duration
+		poolCapacityInterval time.Duration
+		strict               bool
+		cloudEventsSink      string
+		cloudEventsFilter    string
+		fipsMode             bool
+		workerThreads        int
+		domain               string
 	)
 
 	var metricsAddr string
@@ -172,6 +184,16 @@ func main() {
 			"Enabling this will ensure there is only one active dell-replication-controller manager.")
 	flag.DurationVar(&retryIntervalStart, "retry-interval-start", time.Second, "Initial retry interval of failed reconcile request. It doubles with each failure, upto retry-interval-max")
 	flag.DurationVar(&retryIntervalMax, "retry-interval-max", 5*time.Minute, "Maximum retry interval of failed reconcile request")
+	flag.DurationVar(&pvcRequeueMin, "pvc-requeue-min-interval", controllers.DefaultPVCRequeueMinInterval, "Requeue interval of recently changed PVCs, e.g. 30s. It doubles with each reconcile in which the PVC is unchanged, upto pvc-requeue-max-interval. "+
+		"Disabled by default (0): the PVCs are reconciled on every change, and each periodic reconcile also reads the remote PVC. "+
+		"Enable it to sync the PVCs whose remote PVC is created after them without waiting for their next change")
+	flag.DurationVar(&pvcRequeueMax, "pvc-requeue-max-interval", controllers.DefaultPVCRequeueMaxInterval, "Maximum requeue interval of stable PVCs")
+	flag.DurationVar(&rgRepairInterval, "rg-repair-interval", 0, "Interval of the scans for RGs stuck in a half-synced state, whose pairing annotations are then rebuilt from their remote RG. Set to 0 to disable the periodic scans, the repair can still be requested with repctl")
+	flag.DurationVar(&poolCapacityInterval, "pool-capacity-interval", time.Minute, "Interval of the publications of the capacity of the remote pools on the storage classes using the most-free pool placement. Set to 0 to disable the publications, the replica volumes are then placed round-robin")
+	flag.StringVar(&cloudEventsSink, "cloudevents-sink", "", "URL of the sink the RG lifecycle CloudEvents are delivered to. CloudEvents are disabled if empty")
+	flag.StringVar(&cloudEventsFilter, "cloudevents-filter", "", "CESQL expression selecting the RG lifecycle CloudEvents delivered to the sink")
+	flag.BoolVar(&strict, "strict", false, "Report malformed replication annotations as errors instead of falling back to the defaults")
+	flag.BoolVar(&fipsMode, "fips", false, "Enforce FIPS validated crypto for all the TLS connections. Refuses to start if the binary is not built with GOEXPERIMENT=boringcrypto or if a connection is not using verified TLS. The metrics are served over HTTPS")
 	flag.Parse()
 	setupLog.V(common.InfoLevel).Info("Prefix", "Domain", domain)
 	controllers.InitLabelsAndAnnotations(domain)
@@ -185,15 +207,39 @@ func main() {
 	ctrl.SetLogger(logger)
 	setupLog.V(common.InfoLevel).Info(common.DellReplicationController, "Version", core.SemVer, "Commit ID", core.CommitSha32, "Commit SHA", core.CommitTime.Format(time.RFC1123))
 
+	restConfig := ctrl.GetConfigOrDie()
+	var tlsOpts []func(*tls.Config)
+	if fipsMode {
+		// Refuse to operate unless all the TLS connections use FIPS validated crypto
+		if err := fips.Verify(); err != nil {
+			setupLog.Error(err, "unable to enable FIPS mode")
+			os.Exit(1)
+		}
+		if err := fips.VerifyRESTConfig(controllers.Self, restConfig); err != nil {
+			setupLog.Error(err, "unable to enable FIPS mode")
+			os.Exit(1)
+		}
+		if cloudEventsSink != "" {
+			if err := fips.VerifyURL(cloudEventsSink); err != nil {
+				setupLog.Error(err, "unable to enable FIPS mode")
+				os.Exit(1)
+			}
+		}
+		tlsOpts = append(tlsOpts, fips.ConfigureTLS)
+		setupLog.V(common.InfoLevel).Info("FIPS mode enabled")
+	}
+
 	ctx := context.Background()
 
 	// Create the manager instance
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsServer.Options{
-			BindAddress: metricsAddr,
+			BindAddress:   metricsAddr,
+			SecureServing: fipsMode,
+			TLSOpts:       tlsOpts,
 		},
-		WebhookServer:              webhook.NewServer(webhook.Options{Port: 9443}),
+		WebhookServer:              webhook.NewServer(webhook.Options{Port: 9443, TLSOpts: tlsOpts}),
 		LeaderElection:             enableLeaderElection,
 		LeaderElectionResourceLock: "leases",
 		LeaderElectionID:           fmt.Sprintf("%s-manager", common.DellReplicationController),
@@ -203,7 +249,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	controllerMgr, err := createControllerManager(ctx, mgr)
+	controllerMgr, err := createControllerManager(ctx, mgr, fipsMode)
 	if err != nil {
 		setupLog.Error(err, "failed to configure the controller manager")
 		os.Exit(1)
@@ -227,11 +273,29 @@ func main() {
 	}
 
 	expRateLimiter := workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](retryIntervalStart, retryIntervalMax)
+	// All the controllers share the manager's client and informer caches for the local cluster, along with
+	// a single MultiClusterClient. It reuses one direct client, with its circuit breaker, per remote cluster
+	eventRecorder := mgr.GetEventRecorderFor(common.DellReplicationController)
+	var eventEmitter events.Emitter
+	if cloudEventsSink != "" {
+		emitter, err := events.NewCloudEventEmitter(ctrl.Log.WithName("cloudevents"), cloudEventsSink,
+			fmt.Sprintf("/%s/%s", common.DellReplicationController, controllerMgr.config.GetClusterID()), cloudEventsFilter)
+		if err != nil {
+			setupLog.Error(err, "unable to create cloudevents emitter")
+			os.Exit(1)
+		}
+		if err := mgr.Add(emitter); err != nil {
+			setupLog.Error(err, "unable to add cloudevents emitter")
+			os.Exit(1)
+		}
+		eventEmitter = emitter
+	}
 	if err = (&repController.PersistentVolumeClaimReconciler{
 		Client:        mgr.GetClient(),
 		Log:           ctrl.Log.WithName("controllers").WithName("PersistentVolumeClaim"),
 		Scheme:        mgr.GetScheme(),
-		EventRecorder: mgr.GetEventRecorderFor(common.DellReplicationController),
+		EventRecorder: eventRecorder,
+		PVCRequeue:    controllers.NewAdaptiveRequeue(pvcRequeueMin, pvcRequeueMax, controllers.DefaultRequeueJitterFactor),
 		Config:        controllerMgr.config,
 		Domain:        domain,
 	}).SetupWithManager(mgr, expRateLimiter, workerThreads); err != nil {
@@ -243,22 +307,49 @@ func main() {
 		Client:        mgr.GetClient(),
 		Log:           ctrl.Log.WithName("controllers").WithName("DellCSIReplicationGroup"),
 		Scheme:        mgr.GetScheme(),
-		EventRecorder: mgr.GetEventRecorderFor(common.DellReplicationController),
+		EventRecorder: eventRecorder,
 		Config:        controllerMgr.config,
 		Domain:        domain,
+		Strict:        strict,
+		EventEmitter:  eventEmitter,
 	}).SetupWithManager(mgr, expRateLimiter, workerThreads); err != nil {
 		setupLog.Error(err, "unable to create controller", common.DellReplicationController, "DellCSIReplicationGroup")
 		os.Exit(1)
 	}
 
+	if rgRepairInterval > 0 {
+		if err := mgr.Add(&repController.AnnotationRepairer{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("AnnotationRepairer"),
+			Config:   controllerMgr.config,
+			Interval: rgRepairInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to add RG annotation repairer")
+			os.Exit(1)
+		}
+	}
+
+	if poolCapacityInterval > 0 {
+		if err := mgr.Add(&repController.PoolCapacityPublisher{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("PoolCapacityPublisher"),
+			Config:   controllerMgr.config,
+			Interval: poolCapacityInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to add remote pool capacity publisher")
+			os.Exit(1)
+		}
+	}
+
 	// PV Controller
 	if err = (&repController.PersistentVolumeReconciler{
 		Client:        mgr.GetClient(),
 		Log:           ctrl.Log.WithName("controllers").WithName("PersistentVolume"),
 		Scheme:        mgr.GetScheme(),
-		EventRecorder: mgr.GetEventRecorderFor(common.DellReplicationController),
+		EventRecorder: eventRecorder,
 		Config:        controllerMgr.config,
 		Domain:        domain,
+		Strict:        strict,
 	}).SetupWithManager(mgr, expRateLimiter, workerThreads); err != nil {
 		setupLog.Error(err, "unable to create controller", common.DellReplicationController, "PersistentVolume")
 		os.Exit(1)
@@ -16,7 +16,10 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	repv1 "github.com/dell/csm-replication/api/v1"
 	"google.golang.org/grpc/codes"
@@ -99,6 +102,91 @@ func RemoveFinalizerIfExists(obj metav1.Object, name string) bool {
 	return finalizerRemoved
 }
 
+// NormalizeLinkOption converts the user provided value of a replication link option
+// to either LinkOptionEnabled or LinkOptionDisabled
+func NormalizeLinkOption(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case LinkOptionEnabled, "true", "yes":
+		return LinkOptionEnabled, nil
+	case LinkOptionDisabled, "false", "no":
+		return LinkOptionDisabled, nil
+	}
+	return "", fmt.Errorf("invalid replication link option value: %s", value)
+}
+
+// ValidateRetentionPolicy returns an error if the value is neither retain nor delete
+func ValidateRetentionPolicy(value string) error {
+	switch strings.ToLower(value) {
+	case RemoteRetentionValueRetain, RemoteRetentionValueDelete:
+		return nil
+	}
+	return fmt.Errorf("invalid retention policy value: %s", value)
+}
+
+// SetDegradedCondition adds a Degraded condition with the error to the RG,
+// unless the latest condition already reports the same error. Returns true if the conditions were updated
+func SetDegradedCondition(rg *repv1.DellCSIReplicationGroup, err error, maxConditions int) bool {
+	if len(rg.Status.Conditions) > 0 && rg.Status.Conditions[0].Condition == DegradedCondition &&
+		rg.Status.Conditions[0].ErrorMessage == err.Error() {
+		return false
+	}
+	condition := repv1.LastAction{
+		Condition:    DegradedCondition,
+		Time:         &metav1.Time{Time: time.Now()},
+		ErrorMessage: err.Error(),
+	}
+	UpdateConditions(rg, condition, maxConditions)
+	return true
+}
+
+// IsGenericEphemeral returns true if the claim has been created for a generic ephemeral volume of a pod.
+// Such claims are controlled by their pod and deleted together with it
+func IsGenericEphemeral(claim *v1.PersistentVolumeClaim) bool {
+	owner := metav1.GetControllerOf(claim)
+	return owner != nil && owner.APIVersion == "v1" && owner.Kind == "Pod"
+}
+
+// SetReplicationExcludedCondition adds a ReplicationExcluded condition with the reason to the claim,
+// unless it is already set. Returns true if the conditions were updated
+func SetReplicationExcludedCondition(claim *v1.PersistentVolumeClaim, reason, message string) bool {
+	for _, condition := range claim.Status.Conditions {
+		if condition.Type == ReplicationExcludedCondition && condition.Reason == reason {
+			return false
+		}
+	}
+	claim.Status.Conditions = append(claim.Status.Conditions, v1.PersistentVolumeClaimCondition{
+		Type:               ReplicationExcludedCondition,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+	return true
+}
+
+// SetClaimDegradedCondition adds a Degraded condition with the reason to the claim, replacing any previous
+// Degraded condition, unless it is already set. Returns true if the conditions were updated
+func SetClaimDegradedCondition(claim *v1.PersistentVolumeClaim, reason, message string) bool {
+	for i, condition := range claim.Status.Conditions {
+		if condition.Type != DegradedCondition {
+			continue
+		}
+		if condition.Reason == reason && condition.Message == message {
+			return false
+		}
+		claim.Status.Conditions = append(claim.Status.Conditions[:i], claim.Status.Conditions[i+1:]...)
+		break
+	}
+	claim.Status.Conditions = append(claim.Status.Conditions, v1.PersistentVolumeClaimCondition{
+		Type:               DegradedCondition,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+	return true
+}
+
 // IsCSIFinalError return true only if there is no point in retrying
 func IsCSIFinalError(err error) bool {
 	st, ok := status.FromError(err)
@@ -226,6 +314,30 @@ var (
 	RemotePVRetentionPolicy string
 	// RemoteRGRetentionPolicy indicates whether to retain or delete the target RG
 	RemoteRGRetentionPolicy string
+	// LinkCompression indicates whether the replication traffic should be compressed
+	// Used as storage class parameter as well as annotation on the DellCSIReplicationGroup
+	LinkCompression string
+	// LinkEncryption indicates whether the replication traffic should be encrypted in flight
+	// Used as storage class parameter as well as annotation on the DellCSIReplicationGroup
+	LinkEncryption string
+	// ActionProbes contains the probes which must pass after an action, before it is marked as succeeded
+	// Used as storage class parameter as well as annotation on the DellCSIReplicationGroup
+	ActionProbes string
+	// RemotePoolPlacement is the policy selecting the pool of the target array the replica volumes are created in
+	RemotePoolPlacement string
+	// RemotePools is the list of the pools of the target array the replica volumes are placed in
+	RemotePools string
+	// RemotePoolPins is the list of namespace=pool pins used by the pinned placement policy
+	RemotePoolPins string
+	// RemotePoolParameter is the name of the driver parameter selecting the pool of the replica volume
+	RemotePoolParameter string
+	// RemotePool is the pool of the target array the replica volume is placed in, set before the replica volume is created
+	RemotePool string
+	// RemotePoolCapacity is the available capacity of the pools of the target array, published on the storage class
+	// by the replication-controller for the most-free placement
+	RemotePoolCapacity string
+	// RepairRequested requests the rebuild of the pairing annotations of a half-synced DellCSIReplicationGroup
+	RepairRequested string
 	// MigrationRequested  annotation indicates if migration is requested for given volume
 	MigrationRequested string
 	// MigrationNamespace indicates target pvc namespace
@@ -275,6 +387,16 @@ func InitLabelsAndAnnotations(domain string) {
 	SynchronizedDeletionStatus = domain + synchronizedDeletionStatus
 	RemotePVRetentionPolicy = domain + remotePVRetentionPolicy
 	RemoteRGRetentionPolicy = domain + remoteRGRetentionPolicy
+	LinkCompression = domain + linkCompression
+	LinkEncryption = domain + linkEncryption
+	ActionProbes = domain + actionProbes
+	RemotePoolPlacement = domain + remotePoolPlacement
+	RemotePools = domain + remotePools
+	RemotePoolPins = domain + remotePoolPins
+	RemotePoolParameter = domain + remotePoolParameter
+	RemotePool = domain + remotePool
+	RemotePoolCapacity = domain + remotePoolCapacity
+	RepairRequested = domain + repairRequested
 	MigrationRequested = domain + migrateTo
 	MigrationNamespace = domain + migrateNS
 	CreatedByMigrator = domain + createdByMigrator
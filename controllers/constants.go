@@ -23,6 +23,26 @@ const (
 	StorageClassReplicationParamEnabledValue = "true"
 	// DefaultRetryInterval default interval after which controllers reconcile objects
 	DefaultRetryInterval = 2 * time.Second
+	// DefaultPVCRequeueMinInterval default interval after which recently changed PVCs are reconciled again.
+	// The periodic PVC reconciles stay opt-in: the PVCs are reconciled on every change anyway, and the controller
+	// never requeued the reconciled PVCs before, so any non-zero default would add API load instead of cutting it.
+	// Once enabled, e.g. to pick up remote PVCs created after the local one, the adaptive intervals cost far
+	// fewer reconciles than a static interval, as the stable PVCs back off to DefaultPVCRequeueMaxInterval
+	DefaultPVCRequeueMinInterval time.Duration = 0
+	// DefaultPVCRequeueMaxInterval default interval after which stable PVCs are reconciled again
+	DefaultPVCRequeueMaxInterval = 30 * time.Minute
+	// DefaultRequeueJitterFactor maximum fraction of the requeue interval added as jitter
+	DefaultRequeueJitterFactor = 0.2
+	// DegradedCondition condition of the DellCSIReplicationGroup with malformed annotations in strict mode,
+	// and of the PVC whose storage class has malformed replication parameters in strict mode
+	DegradedCondition = "Degraded"
+	// ReplicationExcludedCondition condition of the PVC excluded from replication although its storage class is replication-enabled
+	ReplicationExcludedCondition = "ReplicationExcluded"
+	// ReasonGenericEphemeralVolume reason of the ReplicationExcluded condition of the PVCs of generic ephemeral volumes,
+	// which are deleted with their pod and would leave their replication pair behind on the arrays
+	ReasonGenericEphemeralVolume = "GenericEphemeralVolume"
+	// ReasonMalformedReplicationParameters reason of the Degraded condition of the PVCs whose storage class has malformed replication parameters
+	ReasonMalformedReplicationParameters = "MalformedReplicationParameters"
 
 	storageClassReplicationParam        = "/isReplicationEnabled"
 	storageClassRemoteStorageClassParam = "/remoteStorageClassName"
@@ -101,6 +121,48 @@ const (
 	// RemoteRGRetentionPolicy
 	// Indicates whether to retain or delete the target RG
 	remoteRGRetentionPolicy = "/remoteRGRetentionPolicy"
+	// LinkCompression
+	// Requests compression of the replication traffic between the arrays
+	linkCompression = "/linkCompression"
+	// LinkEncryption
+	// Requests encryption in flight of the replication traffic between the arrays
+	linkEncryption = "/linkEncryption"
+	// LinkOptionEnabled is the value for linkCompression or linkEncryption to mean enabled
+	LinkOptionEnabled = "enabled"
+	// LinkOptionDisabled is the value for linkCompression or linkEncryption to mean disabled
+	LinkOptionDisabled = "disabled"
+	// ActionProbes
+	// JSON list of probes which must pass before an action is marked as succeeded
+	actionProbes = "/actionProbes"
+	// RemotePoolPlacement
+	// Policy selecting the pool of the target array the replica volumes are created in
+	remotePoolPlacement = "/remotePoolPlacement"
+	// RemotePools
+	// Comma separated list of the pools of the target array the replica volumes are placed in
+	remotePools = "/remotePools"
+	// RemotePoolPins
+	// Comma separated list of namespace=pool pins used by the pinned placement policy
+	remotePoolPins = "/remotePoolPins"
+	// RemotePoolParameter
+	// Name of the driver parameter selecting the pool of the replica volume
+	remotePoolParameter = "/remotePoolParameter"
+	// RemotePool
+	// Pool of the target array the replica volume has been placed in
+	remotePool = "/remotePool"
+	// RemotePoolCapacity
+	// Available capacity of the pools of the target array, published on the storage class for the most-free placement
+	remotePoolCapacity = "/remotePoolCapacity"
+	// RepairRequested
+	// Requests the replication-controller to rebuild the pairing annotations of the RG from its remote RG
+	repairRequested = "/repairRequested"
+	// RepairForced is the value for repairRequested to repair the annotations of a remote RG paired with another RG
+	RepairForced = "force"
+	// PlacementRoundRobin is the value for remotePoolPlacement to spread the replica volumes evenly across the pools
+	PlacementRoundRobin = "round-robin"
+	// PlacementMostFree is the value for remotePoolPlacement to place the replica volumes in the pool with the most available capacity
+	PlacementMostFree = "most-free"
+	// PlacementPinned is the value for remotePoolPlacement to place the replica volumes in the pool pinned to their namespace
+	PlacementPinned = "pinned"
 	// Indicates if migration is requested for given volume. Value is the target SC.
 	migrateTo = "/migrate-to"
 	// Indicates target NS for migrated pvc
@@ -0,0 +1,433 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package csireplicator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	repv1 "github.com/dell/csm-replication/api/v1"
+	"github.com/dell/csm-replication/controllers"
+	"github.com/dell/csm-replication/pkg/common"
+	"github.com/dell/csm-replication/pkg/fips"
+	csiext "github.com/dell/dell-csi-extensions/replication"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// DefaultActionProbeTimeout maximum amount of time to wait for an action probe to pass
+	DefaultActionProbeTimeout = 10 * time.Minute
+	// ActionProbeInterval time between consecutive evaluations of the pending action probes
+	ActionProbeInterval = 10 * time.Second
+	// httpProbeTimeout timeout of a single HTTP probe request
+	httpProbeTimeout = 5 * time.Second
+	// maxProbeRedirects maximum number of redirects followed by an HTTP probe
+	maxProbeRedirects = 10
+)
+
+// errProbeFailed is returned by the probes which can't pass anymore, no matter how long we wait
+var errProbeFailed = errors.New("probe failed")
+
+// ActionProbe represents a check which must pass after the driver has successfully executed an action,
+// before the action is marked as succeeded. Exactly one of HTTP, Job and DriverStatus must be set.
+// As anyone who can annotate an RG can configure its probes, the HTTP and Job probes only run against
+// the hosts and in the namespaces allowed by the operator (see ReplicationGroupReconciler)
+type ActionProbe struct {
+	Name string `json:"name"`
+	// Actions the probe applies to, applies to all the actions if empty
+	Actions []string `json:"actions,omitempty"`
+	// Timeout after which a probe which hasn't passed fails the action
+	Timeout      string             `json:"timeout,omitempty"`
+	HTTP         *HTTPProbe         `json:"http,omitempty"`
+	Job          *JobProbe          `json:"job,omitempty"`
+	DriverStatus *DriverStatusProbe `json:"driverStatus,omitempty"`
+}
+
+// HTTPProbe passes once a GET request to the URL returns the expected status code
+type HTTPProbe struct {
+	URL            string `json:"url"`
+	ExpectedStatus int    `json:"expectedStatus,omitempty"`
+}
+
+// JobProbe creates a Job from the spec and passes once the Job completes.
+// The Job runs without a service account token, and can't request any privilege on the node
+type JobProbe struct {
+	Namespace string          `json:"namespace"`
+	Spec      batchv1.JobSpec `json:"spec"`
+}
+
+// DriverStatusProbe passes if the status reported by the driver for the action matches
+type DriverStatusProbe struct {
+	LinkState        string            `json:"linkState,omitempty"`
+	IsSource         *bool             `json:"isSource,omitempty"`
+	ActionAttributes map[string]string `json:"actionAttributes,omitempty"`
+}
+
+func (p *ActionProbe) validate() error {
+	count := 0
+	if p.HTTP != nil {
+		count++
+	}
+	if p.Job != nil {
+		count++
+	}
+	if p.DriverStatus != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("probe %s must specify exactly one of http, job or driverStatus", p.Name)
+	}
+	if p.Job != nil {
+		if p.Job.Namespace == "" {
+			return fmt.Errorf("probe %s must specify the namespace of the job", p.Name)
+		}
+		if err := validateProbePodSpec(&p.Job.Spec.Template.Spec); err != nil {
+			return fmt.Errorf("probe %s has an invalid job spec: %s", p.Name, err.Error())
+		}
+	}
+	if p.Timeout != "" {
+		if _, err := time.ParseDuration(p.Timeout); err != nil {
+			return fmt.Errorf("probe %s has an invalid timeout: %s", p.Name, err.Error())
+		}
+	}
+	return nil
+}
+
+func (p *ActionProbe) getTimeout() time.Duration {
+	if timeout, err := time.ParseDuration(p.Timeout); err == nil {
+		return timeout
+	}
+	return DefaultActionProbeTimeout
+}
+
+func (p *ActionProbe) appliesTo(actionType ActionType) bool {
+	if len(p.Actions) == 0 {
+		return true
+	}
+	for _, action := range p.Actions {
+		if ActionType(action).String() == actionType.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// getActionProbes returns the probes configured on the RG for the given action
+func getActionProbes(rg *repv1.DellCSIReplicationGroup, actionType ActionType) ([]ActionProbe, error) {
+	val, ok := rg.Annotations[controllers.ActionProbes]
+	if !ok || val == "" {
+		return nil, nil
+	}
+	probes, err := parseActionProbes(val)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]ActionProbe, 0)
+	for _, probe := range probes {
+		if probe.appliesTo(actionType) {
+			result = append(result, probe)
+		}
+	}
+	return result, nil
+}
+
+// parseActionProbes parses and validates the JSON list of action probes
+func parseActionProbes(val string) ([]ActionProbe, error) {
+	var probes []ActionProbe
+	if err := json.Unmarshal([]byte(val), &probes); err != nil {
+		return nil, fmt.Errorf("failed to parse the action probes: %s", err.Error())
+	}
+	for i := range probes {
+		if err := probes[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	return probes, nil
+}
+
+// runActionProbes evaluates the probes configured for the executed action.
+// Returns true if any of the probes is still pending; a non-nil error means that the action must be marked as failed
+func (r *ReplicationGroupReconciler) runActionProbes(ctx context.Context, rg *repv1.DellCSIReplicationGroup, result *ActionResult) (bool, error) {
+	log := common.GetLoggerFromContext(ctx)
+
+	probes, err := getActionProbes(rg, result.ActionType)
+	if err != nil {
+		log.Error(err, "Invalid action probes")
+		return false, err
+	}
+	for i := range probes {
+		probe := probes[i]
+		passed, err := r.evaluateProbe(ctx, rg, &probe, result)
+		if passed {
+			log.V(common.InfoLevel).Info("Action probe passed", "probe", probe.Name)
+			continue
+		}
+		if errors.Is(err, errProbeFailed) {
+			return false, fmt.Errorf("action probe %s failed: %s", probe.Name, err.Error())
+		}
+		if time.Since(result.Time) > probe.getTimeout() {
+			return false, fmt.Errorf("action probe %s did not pass within %s: %v", probe.Name, probe.getTimeout(), err)
+		}
+		log.V(common.InfoLevel).Info("Action probe pending", "probe", probe.Name, "reason", err)
+		return true, nil
+	}
+	return false, nil
+}
+
+// validateProbePodSpec rejects the pod specs which run with a service account or with privileges on the node
+func validateProbePodSpec(spec *v1.PodSpec) error {
+	if spec.ServiceAccountName != "" || spec.DeprecatedServiceAccount != "" {
+		return errors.New("serviceAccountName is not allowed")
+	}
+	if spec.HostNetwork || spec.HostPID || spec.HostIPC {
+		return errors.New("host namespaces are not allowed")
+	}
+	for _, volume := range spec.Volumes {
+		if volume.HostPath != nil {
+			return fmt.Errorf("hostPath volume %s is not allowed", volume.Name)
+		}
+	}
+	containers := append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		securityContext := container.SecurityContext
+		if securityContext == nil {
+			continue
+		}
+		if (securityContext.Privileged != nil && *securityContext.Privileged) ||
+			(securityContext.AllowPrivilegeEscalation != nil && *securityContext.AllowPrivilegeEscalation) {
+			return fmt.Errorf("container %s must not be privileged", container.Name)
+		}
+		if securityContext.Capabilities != nil && len(securityContext.Capabilities.Add) != 0 {
+			return fmt.Errorf("container %s must not add capabilities", container.Name)
+		}
+	}
+	if len(spec.EphemeralContainers) != 0 {
+		return errors.New("ephemeral containers are not allowed")
+	}
+	return nil
+}
+
+func (r *ReplicationGroupReconciler) evaluateProbe(ctx context.Context, rg *repv1.DellCSIReplicationGroup, probe *ActionProbe, result *ActionResult) (bool, error) {
+	switch {
+	case probe.HTTP != nil:
+		return r.probeHTTP(ctx, probe.HTTP)
+	case probe.Job != nil:
+		return r.probeJob(ctx, rg, probe, result.Time)
+	default:
+		return probeDriverStatus(probe.DriverStatus, result)
+	}
+}
+
+// verifyProbeURL returns an error unless the HTTP probes can send requests to the URL
+func (r *ReplicationGroupReconciler) verifyProbeURL(probeURL *url.URL) error {
+	if probeURL.Scheme != "http" && probeURL.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported scheme %q", errProbeFailed, probeURL.Scheme)
+	}
+	if !slices.Contains(r.ProbeHTTPHosts, probeURL.Host) && !slices.Contains(r.ProbeHTTPHosts, probeURL.Hostname()) {
+		return fmt.Errorf("%w: host %s is not allowed for HTTP probes", errProbeFailed, probeURL.Host)
+	}
+	if r.FIPS {
+		if err := fips.VerifyURL(probeURL.String()); err != nil {
+			return fmt.Errorf("%w: %s", errProbeFailed, err.Error())
+		}
+	}
+	return nil
+}
+
+func (r *ReplicationGroupReconciler) probeHTTP(ctx context.Context, probe *HTTPProbe) (bool, error) {
+	probeURL, err := url.Parse(probe.URL)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", errProbeFailed, err.Error())
+	}
+	if err := r.verifyProbeURL(probeURL); err != nil {
+		return false, err
+	}
+	httpClient := http.DefaultClient
+	if r.HTTPProbeClient != nil {
+		httpClient = r.HTTPProbeClient
+	}
+	// The redirects must not escape the allowed hosts either
+	probeClient := *httpClient
+	probeClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxProbeRedirects {
+			return fmt.Errorf("%w: stopped after %d redirects", errProbeFailed, maxProbeRedirects)
+		}
+		return r.verifyProbeURL(req.URL)
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, httpProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(tctx, http.MethodGet, probe.URL, nil)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", errProbeFailed, err.Error())
+	}
+	resp, err := probeClient.Do(req)
+	if errors.Is(err, errProbeFailed) {
+		return false, fmt.Errorf("%w: %s", errProbeFailed, err.Error())
+	} else if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	expectedStatus := probe.ExpectedStatus
+	if expectedStatus == 0 {
+		expectedStatus = http.StatusOK
+	}
+	if resp.StatusCode != expectedStatus {
+		return false, fmt.Errorf("GET %s returned %d, expected %d", probe.URL, resp.StatusCode, expectedStatus)
+	}
+	return true, nil
+}
+
+func (r *ReplicationGroupReconciler) probeJob(ctx context.Context, rg *repv1.DellCSIReplicationGroup, probe *ActionProbe, executionTime time.Time) (bool, error) {
+	log := common.GetLoggerFromContext(ctx)
+
+	if !slices.Contains(r.ProbeJobNamespaces, probe.Job.Namespace) {
+		return false, fmt.Errorf("%w: namespace %s is not allowed for job probes", errProbeFailed, probe.Job.Namespace)
+	}
+
+	name := getProbeJobName(rg.Name, probe.Name, executionTime)
+	job := new(batchv1.Job)
+	err := r.Get(ctx, types.NamespacedName{Namespace: probe.Job.Namespace, Name: name}, job)
+	if apierrors.IsNotFound(err) {
+		job = &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: probe.Job.Namespace,
+				Labels: map[string]string{
+					controllers.ReplicationGroup: rg.Name,
+				},
+			},
+			Spec: *probe.Job.Spec.DeepCopy(),
+		}
+		automountServiceAccountToken := false
+		job.Spec.Template.Spec.AutomountServiceAccountToken = &automountServiceAccountToken
+		if err := controllerutil.SetOwnerReference(rg, job, r.Scheme); err != nil {
+			return false, err
+		}
+		if err := r.Create(ctx, job); err != nil {
+			log.Error(err, "Failed to create the probe job", "job", name)
+			return false, err
+		}
+		return false, fmt.Errorf("job %s/%s created", probe.Job.Namespace, name)
+	} else if err != nil {
+		return false, err
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return false, fmt.Errorf("%w: job %s/%s failed: %s", errProbeFailed, probe.Job.Namespace, name, condition.Message)
+		}
+	}
+	return false, fmt.Errorf("job %s/%s has not completed yet", probe.Job.Namespace, name)
+}
+
+// getProbeJobName returns a name which is unique for each execution of an action
+func getProbeJobName(rgName, probeName string, executionTime time.Time) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%d", rgName, probeName, executionTime.Unix())))
+	return "rg-probe-" + hex.EncodeToString(sum[:8])
+}
+
+func probeDriverStatus(probe *DriverStatusProbe, result *ActionResult) (bool, error) {
+	if probe.LinkState != "" || probe.IsSource != nil {
+		if result.PGStatus == nil {
+			return false, fmt.Errorf("%w: driver didn't report the protection group status", errProbeFailed)
+		}
+		if probe.LinkState != "" && !strings.EqualFold(result.PGStatus.State.String(), probe.LinkState) {
+			return false, fmt.Errorf("%w: link state is %s, expected %s", errProbeFailed, result.PGStatus.State.String(), probe.LinkState)
+		}
+		if probe.IsSource != nil && result.PGStatus.IsSource != *probe.IsSource {
+			return false, fmt.Errorf("%w: isSource is %v, expected %v", errProbeFailed, result.PGStatus.IsSource, *probe.IsSource)
+		}
+	}
+	for key, value := range probe.ActionAttributes {
+		if result.ActionAttributes[key] != value {
+			return false, fmt.Errorf("%w: action attribute %s is %q, expected %q", errProbeFailed, key, result.ActionAttributes[key], value)
+		}
+	}
+	return true, nil
+}
+
+// waitForActionProbes records that the action has been executed, so that it isn't executed again
+// while the probes are pending, and requeues the RG to evaluate the probes again
+func (r *ReplicationGroupReconciler) waitForActionProbes(ctx context.Context, rg *repv1.DellCSIReplicationGroup,
+	inProgress *ActionAnnotation, result *ActionResult,
+) (ctrl.Result, error) {
+	log := common.GetLoggerFromContext(ctx)
+
+	if inProgress.ExecutionTime == "" {
+		buff, _ := json.Marshal(&metav1.Time{Time: result.Time})
+		inProgress.ExecutionTime = string(buff)
+		if result.PGStatus != nil {
+			buff, _ = json.Marshal(result.PGStatus)
+			inProgress.ProtectionGroupStatus = string(buff)
+		}
+		inProgress.ActionAttributes = result.ActionAttributes
+		bytes, _ := json.Marshal(inProgress)
+		controllers.AddAnnotation(rg, Action, string(bytes))
+		if err := r.Update(ctx, rg); err != nil {
+			log.Error(err, "Failed to record the action execution")
+			return ctrl.Result{}, err
+		}
+		r.EventRecorder.Eventf(rg, v1.EventTypeNormal, "Verifying",
+			"Action [%s] on DellCSIReplicationGroup [%s] executed, waiting for the action probes to pass",
+			result.ActionType.String(), rg.Name)
+	}
+	return ctrl.Result{RequeueAfter: ActionProbeInterval}, nil
+}
+
+// getActionResultFromExecutedAction rebuilds the result of an action which has been executed by the driver,
+// but is still waiting for the action probes to pass
+func getActionResultFromExecutedAction(actionAnnotation ActionAnnotation) (*ActionResult, error) {
+	var executionTime metav1.Time
+	if err := json.Unmarshal([]byte(actionAnnotation.ExecutionTime), &executionTime); err != nil {
+		return nil, err
+	}
+	var pgStatus *csiext.StorageProtectionGroupStatus
+	if actionAnnotation.ProtectionGroupStatus != "" {
+		pgStatus = new(csiext.StorageProtectionGroupStatus)
+		if err := json.Unmarshal([]byte(actionAnnotation.ProtectionGroupStatus), pgStatus); err != nil {
+			return nil, err
+		}
+	}
+	return &ActionResult{
+		ActionType:       ActionType(actionAnnotation.ActionName),
+		Time:             executionTime.Time,
+		PGStatus:         pgStatus,
+		ActionAttributes: actionAnnotation.ActionAttributes,
+	}, nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -25,6 +26,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 
 	csiext "github.com/dell/dell-csi-extensions/replication"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	reconciler "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -40,6 +43,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/dell/csm-replication/controllers"
+	"github.com/dell/csm-replication/pkg/events"
 )
 
 const (
@@ -112,6 +116,9 @@ type ActionAnnotation struct {
 	ProtectionGroupStatus string `json:"protectionGroupStatus"`
 	SnapshotNamespace     string `json:"snapshotNamespace"`
 	SnapshotClass         string `json:"snapshotClass"`
+	// ExecutionTime is set once the driver has executed the action while the action probes are pending
+	ExecutionTime    string            `json:"executionTime,omitempty"`
+	ActionAttributes map[string]string `json:"actionAttributes,omitempty"`
 }
 
 func updateRGSpecWithActionResult(ctx context.Context, rg *repv1.DellCSIReplicationGroup, result *ActionResult) bool {
@@ -311,11 +318,25 @@ type ReplicationGroupReconciler struct {
 	ReplicationClient          csireplication.Replication
 	SupportedActions           []*csiext.SupportedActions
 	MaxRetryDurationForActions time.Duration
+	// Strict reports malformed annotations with a Degraded condition instead of falling back to the defaults
+	Strict bool
+	// EventEmitter emits the lifecycle events of the RGs, disabled if nil
+	EventEmitter events.Emitter
+	// HTTPProbeClient sends the requests of the HTTP action probes, http.DefaultClient if nil
+	HTTPProbeClient *http.Client
+	// FIPS fails the HTTP action probes which are not using TLS
+	FIPS bool
+	// ProbeHTTPHosts the hosts (host or host:port) the HTTP action probes can send GET requests to.
+	// HTTP probes are disabled if empty, as anyone who can annotate an RG could otherwise make the sidecar call any URL
+	ProbeHTTPHosts []string
+	// ProbeJobNamespaces the namespaces the Job action probes can run in, Job probes are disabled if empty
+	ProbeJobNamespaces []string
 }
 
 // +kubebuilder:rbac:groups=replication.storage.dell.com,resources=dellcsireplicationgroups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=replication.storage.dell.com,resources=dellcsireplicationgroups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=list;watch;create;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 
 // Reconcile contains reconciliation logic that updates ReplicationGroup depending on it's current state
 func (r *ReplicationGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -489,6 +510,9 @@ func (r *ReplicationGroupReconciler) processRGInActionInProgressState(ctx contex
 	log := common.GetLoggerFromContext(ctx)
 	// Get action in progress from annotation
 	inProgress, err := getActionInProgress(ctx, rg.Annotations)
+	if err != nil && r.Strict {
+		return r.reportDegraded(ctx, rg, fmt.Errorf("malformed %s annotation: %s", Action, err.Error()))
+	}
 	if err != nil || inProgress == nil {
 		// Either the annotation is not set or not set properly
 		// Mostly points to User error
@@ -570,8 +594,30 @@ func (r *ReplicationGroupReconciler) processRGInActionInProgressState(ctx contex
 		err = r.updateState(ctx, rg.DeepCopy(), ErrorState)
 		return ctrl.Result{}, err
 	}
-	// Make API call to Execute Action
-	actionResult := r.executeAction(ctx, rg.DeepCopy(), actionType, action)
+	var actionResult *ActionResult
+	if inProgress.ExecutionTime != "" {
+		// The action has already been executed, only the action probes are pending
+		actionResult, err = getActionResultFromExecutedAction(*inProgress)
+		if err != nil {
+			log.Error(err, "Failed to parse the executed action", "annotation", inProgress)
+			return ctrl.Result{}, err
+		}
+	} else {
+		// Make API call to Execute Action
+		actionResult = r.executeAction(ctx, rg.DeepCopy(), actionType, action)
+	}
+	if actionResult.Error == nil {
+		pending, err := r.runActionProbes(ctx, rg, actionResult)
+		if pending {
+			return r.waitForActionProbes(ctx, rg, inProgress, actionResult)
+		}
+		if err != nil {
+			// A failed probe is final, retrying won't help as the action has already been executed
+			actionResult.Error = status.Error(codes.FailedPrecondition, err.Error())
+			actionResult.IsFinalError = true
+			actionResult.Time = time.Now()
+		}
+	}
 	if actionResult.Error != nil {
 		// Raise event in case of an error
 		r.EventRecorder.Eventf(rg, v1.EventTypeWarning, "Error",
@@ -601,6 +647,9 @@ func (r *ReplicationGroupReconciler) processRGInActionInProgressState(ctx contex
 	}
 
 	log.Info("Successfully updated status", "state", rg.Status.State)
+	if actionResult.Error == nil || actionResult.IsFinalError {
+		r.emitActionCompleted(rg, actionResult)
+	}
 	// In case of Action success & successful status update, we raise an event
 	if actionResult.Error == nil {
 		r.EventRecorder.Eventf(rg, v1.EventTypeNormal, "Updated",
@@ -610,6 +659,18 @@ func (r *ReplicationGroupReconciler) processRGInActionInProgressState(ctx contex
 	return ctrl.Result{}, controllers.IgnoreIfFinalError(actionResult.Error)
 }
 
+func (r *ReplicationGroupReconciler) emitActionCompleted(rg *repv1.DellCSIReplicationGroup, result *ActionResult) {
+	data := map[string]string{
+		"action": result.ActionType.String(),
+		"result": "succeeded",
+	}
+	if result.Error != nil {
+		data["result"] = "failed"
+		data["error"] = result.Error.Error()
+	}
+	events.Emit(r.EventEmitter, events.TypeRGActionCompleted, rg, data)
+}
+
 func (r *ReplicationGroupReconciler) executeAction(ctx context.Context, rg *repv1.DellCSIReplicationGroup,
 	actionType ActionType, action *csiext.ExecuteActionRequest_Action,
 ) *ActionResult {
@@ -664,6 +725,9 @@ func (r *ReplicationGroupReconciler) processRG(ctx context.Context, dellCSIRepli
 		dellCSIReplicationGroup.Spec.Action != "" {
 		// Get action in progress from annotation
 		inProgress, err := getActionInProgress(ctx, dellCSIReplicationGroup.Annotations)
+		if err != nil && r.Strict {
+			return r.reportDegraded(ctx, dellCSIReplicationGroup, fmt.Errorf("malformed %s annotation: %s", Action, err.Error()))
+		}
 		if err != nil {
 			// We need to decide what to do here
 			// Maybe best effort for what is set in the action field
@@ -689,6 +753,11 @@ func (r *ReplicationGroupReconciler) processRG(ctx context.Context, dellCSIRepli
 			log.Error(err, "Can not proceed with reconcile!", "actionType", actionType)
 			return ctrl.Result{}, nil
 		}
+		if r.Strict && actionType.Equals(ctx, csiext.ActionTypes_CREATE_SNAPSHOT.String()) &&
+			dellCSIReplicationGroup.Annotations[controllers.SnapshotClass] == "" {
+			return r.reportDegraded(ctx, dellCSIReplicationGroup,
+				fmt.Errorf("action %s requires the %s annotation", actionType.String(), controllers.SnapshotClass))
+		}
 		// No annotation means we are getting the action call for the first time
 		// Annotation with completed set to true means that the older action is complete
 		// In both case, we set the Action annotation to "InProgress"
@@ -702,8 +771,13 @@ func (r *ReplicationGroupReconciler) processRG(ctx context.Context, dellCSIRepli
 			controllers.AddAnnotation(dellCSIReplicationGroup, Action, string(bytes))
 			log.V(common.InfoLevel).Info("Updating", "annotation", string(bytes))
 			err := r.Update(ctx, dellCSIReplicationGroup)
-			log.Error(err, "Failed to update", "annotation", string(bytes))
-			return ctrl.Result{}, err
+			if err != nil {
+				log.Error(err, "Failed to update", "annotation", string(bytes))
+				return ctrl.Result{}, err
+			}
+			events.Emit(r.EventEmitter, events.TypeRGActionStarted, dellCSIReplicationGroup,
+				map[string]string{"action": actionType.String()})
+			return ctrl.Result{}, nil
 		}
 		// Action is in progress but not completed yet
 		// We just update the state to match
@@ -725,6 +799,21 @@ func (r *ReplicationGroupReconciler) processRG(ctx context.Context, dellCSIRepli
 	return ctrl.Result{}, nil
 }
 
+// reportDegraded raises an event & adds a Degraded condition to the RG for a malformed annotation.
+// The RG isn't processed any further until the annotation is fixed
+func (r *ReplicationGroupReconciler) reportDegraded(ctx context.Context, rg *repv1.DellCSIReplicationGroup, err error) (ctrl.Result, error) {
+	log := common.GetLoggerFromContext(ctx)
+	log.Error(err, "Malformed annotation in strict mode")
+	r.EventRecorder.Event(rg, v1.EventTypeWarning, controllers.DegradedCondition, err.Error())
+	if controllers.SetDegradedCondition(rg, err, MaxNumberOfConditions) {
+		if err := r.Status().Update(ctx, rg.DeepCopy()); err != nil {
+			log.Error(err, "Failed to update the conditions")
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
 func (r *ReplicationGroupReconciler) processRGInNoState(ctx context.Context, dellCSIReplicationGroup *repv1.DellCSIReplicationGroup) (ctrl.Result, error) {
 	ok, err := r.addFinalizer(ctx, dellCSIReplicationGroup.DeepCopy())
 	if err != nil {
@@ -733,6 +822,7 @@ func (r *ReplicationGroupReconciler) processRGInNoState(ctx context.Context, del
 	if ok {
 		return ctrl.Result{Requeue: true}, nil
 	}
+	dellCSIReplicationGroup.Status.LinkOptions = getLinkOptions(dellCSIReplicationGroup)
 	if err := r.updateState(ctx, dellCSIReplicationGroup.DeepCopy(), ReadyState); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -760,5 +850,8 @@ func (r *ReplicationGroupReconciler) processRGForDeletion(ctx context.Context, d
 		return ctrl.Result{}, nil
 	}
 	err := r.removeFinalizer(ctx, dellCSIReplicationGroup.DeepCopy())
+	if err == nil {
+		events.Emit(r.EventEmitter, events.TypeRGDeleted, dellCSIReplicationGroup, nil)
+	}
 	return ctrl.Result{}, err
 }
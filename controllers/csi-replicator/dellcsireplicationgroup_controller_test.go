@@ -18,6 +18,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -32,8 +34,11 @@ import (
 	constants "github.com/dell/csm-replication/pkg/common"
 	csiidentity "github.com/dell/csm-replication/pkg/csi-clients/identity"
 	csireplication "github.com/dell/csm-replication/pkg/csi-clients/replication"
+	"github.com/dell/csm-replication/pkg/events"
 	"github.com/dell/csm-replication/test/e2e-framework/utils"
 	"github.com/stretchr/testify/suite"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -1198,3 +1203,297 @@ func (suite *RGControllerTestSuite) TestSetupWithManagerRg() {
 	err := suite.rgReconcile.SetupWithManager(mgr, expRateLimiter, 1)
 	suite.Error(err, "Setup should fail when there is no manager")
 }
+
+func (suite *RGControllerTestSuite) setActionProbes(name string, probes []ActionProbe) {
+	rg := new(repv1.DellCSIReplicationGroup)
+	err := suite.client.Get(context.Background(), types.NamespacedName{Name: name}, rg)
+	suite.NoError(err, "No error on RG Get")
+	bytes, _ := json.Marshal(probes)
+	rg.Annotations[controllers.ActionProbes] = string(bytes)
+	err = suite.client.Update(context.Background(), rg)
+	suite.NoError(err, "No error on RG update")
+}
+
+func (suite *RGControllerTestSuite) TestActionInProgressWithPendingHTTPProbe() {
+	// scenario: Action executed successfully, but the HTTP probe passes only on the second attempt
+	actionName := "Failover_Local"
+	healthy := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	replicationGroup := suite.createRGInActionInProgressState("", actionName, false, false)
+	suite.setActionProbes(replicationGroup.Name, []ActionProbe{
+		{Name: "app-health", HTTP: &HTTPProbe{URL: server.URL}},
+	})
+	req := suite.getTypicalReconcileRequest(replicationGroup.Name)
+	suite.repClient.SetCondition(csireplication.ExecuteActionWithSwap)
+	suite.rgReconcile.ProbeHTTPHosts = []string{strings.TrimPrefix(server.URL, "http://")}
+
+	res, err := suite.rgReconcile.Reconcile(context.Background(), req)
+	suite.NoError(err, "No error on RG reconcile")
+	suite.Equal(ActionProbeInterval, res.RequeueAfter, "Requeued to evaluate the probes")
+
+	rg := new(repv1.DellCSIReplicationGroup)
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err, "No error on RG Get")
+	suite.Equal(ActionType(actionName).getInProgressState(), rg.Status.State, "Action still in progress")
+	var gotAnnotation ActionAnnotation
+	err = json.Unmarshal([]byte(rg.GetAnnotations()[Action]), &gotAnnotation)
+	suite.NoError(err, "No error on JSON unmarshal of action annotation")
+	suite.False(gotAnnotation.Completed, "Action not completed")
+	suite.NotEmpty(gotAnnotation.ExecutionTime, "Execution time recorded")
+
+	// Driver must not be called again while the probes are pending
+	suite.repClient.InjectError(status.Error(codes.Internal, "action executed twice"))
+	healthy = true
+	_, err = suite.rgReconcile.Reconcile(context.Background(), req)
+	suite.NoError(err, "No error on RG reconcile")
+	suite.repClient.ClearErrorAndCondition(true)
+
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err, "No error on RG Get")
+	suite.Equal(ReadyState, rg.Status.State, "State is Ready")
+	suite.Equal("", rg.Spec.Action, "Action field set to empty")
+}
+
+func (suite *RGControllerTestSuite) TestActionInProgressWithHTTPProbeInFIPSMode() {
+	// scenario: Action executed successfully, but the HTTP probe isn't using TLS in FIPS mode
+	actionName := "Failover_Local"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	replicationGroup := suite.createRGInActionInProgressState("", actionName, false, false)
+	suite.setActionProbes(replicationGroup.Name, []ActionProbe{
+		{Name: "app-health", HTTP: &HTTPProbe{URL: server.URL}},
+	})
+	req := suite.getTypicalReconcileRequest(replicationGroup.Name)
+	suite.repClient.SetCondition(csireplication.ExecuteActionWithSwap)
+
+	suite.rgReconcile.FIPS = true
+	suite.rgReconcile.ProbeHTTPHosts = []string{strings.TrimPrefix(server.URL, "http://")}
+	_, err := suite.rgReconcile.Reconcile(context.Background(), req)
+	suite.NoError(err, "No error on RG reconcile")
+
+	rg := new(repv1.DellCSIReplicationGroup)
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err, "No error on RG Get")
+	var gotAnnotation ActionAnnotation
+	err = json.Unmarshal([]byte(rg.GetAnnotations()[Action]), &gotAnnotation)
+	suite.NoError(err, "No error on JSON unmarshal of action annotation")
+	suite.Contains(gotAnnotation.FinalError, "is not using TLS")
+}
+
+func (suite *RGControllerTestSuite) TestActionInProgressWithFailedDriverStatusProbe() {
+	// scenario: Action executed successfully, but the status reported by the driver doesn't match the probe
+	actionName := "Failover_Local"
+	actionType := ActionType(actionName)
+	replicationGroup := suite.createRGInActionInProgressState("", actionName, false, false)
+	suite.setActionProbes(replicationGroup.Name, []ActionProbe{
+		{Name: "ignored", Actions: []string{"Reprotect_Local"}, HTTP: &HTTPProbe{URL: "http://127.0.0.1:1"}},
+		{Name: "driver", DriverStatus: &DriverStatusProbe{ActionAttributes: map[string]string{"mode": "async"}}},
+	})
+	req := suite.getTypicalReconcileRequest(replicationGroup.Name)
+	suite.repClient.SetCondition(csireplication.ExecuteActionWithSwap)
+
+	_, err := suite.rgReconcile.Reconcile(context.Background(), req)
+	suite.NoError(err, "No error on RG reconcile")
+
+	rg := new(repv1.DellCSIReplicationGroup)
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err, "No error on RG Get")
+	suite.Equal(ErrorState, rg.Status.State, "State is in error")
+	var gotAnnotation ActionAnnotation
+	err = json.Unmarshal([]byte(rg.GetAnnotations()[Action]), &gotAnnotation)
+	suite.NoError(err, "No error on JSON unmarshal of action annotation")
+	suite.True(gotAnnotation.Completed, "Action completed")
+	suite.Contains(gotAnnotation.FinalError, "action probe driver failed")
+	suite.Contains(rg.Status.LastAction.Condition, actionType.String(), "Last Action was updated")
+}
+
+func (suite *RGControllerTestSuite) TestActionInProgressWithInvalidProbes() {
+	actionName := "Failover_Local"
+	replicationGroup := suite.createRGInActionInProgressState("", actionName, false, false)
+	suite.setActionProbes(replicationGroup.Name, []ActionProbe{{Name: "empty"}})
+	req := suite.getTypicalReconcileRequest(replicationGroup.Name)
+	suite.repClient.SetCondition(csireplication.ExecuteActionWithSwap)
+
+	_, err := suite.rgReconcile.Reconcile(context.Background(), req)
+	suite.NoError(err, "No error on RG reconcile")
+
+	rg := new(repv1.DellCSIReplicationGroup)
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err, "No error on RG Get")
+	suite.Equal(ErrorState, rg.Status.State, "State is in error")
+}
+
+func (suite *RGControllerTestSuite) TestActionInProgressWithHTTPProbeToDisallowedHost() {
+	// scenario: Action executed successfully, but the HTTP probe redirects to a host which isn't allowed
+	actionName := "Failover_Local"
+	disallowed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer disallowed.Close()
+	server := httptest.NewServer(http.RedirectHandler(disallowed.URL, http.StatusFound))
+	defer server.Close()
+
+	replicationGroup := suite.createRGInActionInProgressState("", actionName, false, false)
+	suite.setActionProbes(replicationGroup.Name, []ActionProbe{
+		{Name: "app-health", HTTP: &HTTPProbe{URL: server.URL}},
+	})
+	req := suite.getTypicalReconcileRequest(replicationGroup.Name)
+	suite.repClient.SetCondition(csireplication.ExecuteActionWithSwap)
+	suite.rgReconcile.ProbeHTTPHosts = []string{strings.TrimPrefix(server.URL, "http://")}
+
+	_, err := suite.rgReconcile.Reconcile(context.Background(), req)
+	suite.NoError(err, "No error on RG reconcile")
+
+	rg := new(repv1.DellCSIReplicationGroup)
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err, "No error on RG Get")
+	suite.Equal(ErrorState, rg.Status.State, "State is in error")
+	var gotAnnotation ActionAnnotation
+	err = json.Unmarshal([]byte(rg.GetAnnotations()[Action]), &gotAnnotation)
+	suite.NoError(err, "No error on JSON unmarshal of action annotation")
+	suite.Contains(gotAnnotation.FinalError, "is not allowed for HTTP probes")
+}
+
+func (suite *RGControllerTestSuite) TestActionInProgressWithJobProbe() {
+	// scenario: Action executed successfully, the action succeeds once the probe job completes
+	actionName := "Failover_Local"
+	replicationGroup := suite.createRGInActionInProgressState("", actionName, false, false)
+	suite.setActionProbes(replicationGroup.Name, []ActionProbe{
+		{Name: "app-check", Job: &JobProbe{Namespace: "app", Spec: batchv1.JobSpec{
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "check", Image: "busybox"}}}},
+		}}},
+	})
+	req := suite.getTypicalReconcileRequest(replicationGroup.Name)
+	suite.repClient.SetCondition(csireplication.ExecuteActionWithSwap)
+	suite.rgReconcile.ProbeJobNamespaces = []string{"app"}
+
+	res, err := suite.rgReconcile.Reconcile(context.Background(), req)
+	suite.NoError(err, "No error on RG reconcile")
+	suite.Equal(ActionProbeInterval, res.RequeueAfter, "Requeued to evaluate the probes")
+
+	jobs := new(batchv1.JobList)
+	err = suite.client.List(context.Background(), jobs, client.InNamespace("app"))
+	suite.NoError(err, "No error on Job list")
+	suite.Len(jobs.Items, 1, "Probe job created")
+	job := &jobs.Items[0]
+	suite.False(*job.Spec.Template.Spec.AutomountServiceAccountToken, "Probe job runs without a service account token")
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}
+	err = suite.client.Status().Update(context.Background(), job)
+	suite.NoError(err, "No error on Job status update")
+	_, err = suite.rgReconcile.Reconcile(context.Background(), req)
+	suite.NoError(err, "No error on RG reconcile")
+
+	rg := new(repv1.DellCSIReplicationGroup)
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err, "No error on RG Get")
+	suite.Equal(ReadyState, rg.Status.State, "State is Ready")
+}
+
+func (suite *RGControllerTestSuite) TestActionInProgressWithJobProbeInDisallowedNamespace() {
+	actionName := "Failover_Local"
+	replicationGroup := suite.createRGInActionInProgressState("", actionName, false, false)
+	suite.setActionProbes(replicationGroup.Name, []ActionProbe{
+		{Name: "app-check", Job: &JobProbe{Namespace: "kube-system"}},
+	})
+	req := suite.getTypicalReconcileRequest(replicationGroup.Name)
+	suite.repClient.SetCondition(csireplication.ExecuteActionWithSwap)
+	suite.rgReconcile.ProbeJobNamespaces = []string{"app"}
+
+	_, err := suite.rgReconcile.Reconcile(context.Background(), req)
+	suite.NoError(err, "No error on RG reconcile")
+
+	rg := new(repv1.DellCSIReplicationGroup)
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err, "No error on RG Get")
+	suite.Equal(ErrorState, rg.Status.State, "State is in error")
+
+	jobs := new(batchv1.JobList)
+	err = suite.client.List(context.Background(), jobs)
+	suite.NoError(err, "No error on Job list")
+	suite.Empty(jobs.Items, "No probe job created")
+}
+
+func (suite *RGControllerTestSuite) TestParseActionProbesValidatesJobProbes() {
+	_, err := parseActionProbes(`[{"name": "job", "job": {"spec": {}}}]`)
+	suite.ErrorContains(err, "must specify the namespace of the job")
+
+	_, err = parseActionProbes(`[{"name": "job", "job": {"namespace": "app", "spec": {"template": {"spec": {"serviceAccountName": "admin"}}}}}]`)
+	suite.ErrorContains(err, "serviceAccountName is not allowed")
+
+	_, err = parseActionProbes(`[{"name": "job", "job": {"namespace": "app", "spec": {"template": {"spec": {"hostNetwork": true}}}}}]`)
+	suite.ErrorContains(err, "host namespaces are not allowed")
+
+	_, err = parseActionProbes(`[{"name": "job", "job": {"namespace": "app", "spec": {"template": {"spec": {"containers": [{"name": "check", "securityContext": {"privileged": true}}]}}}}}]`)
+	suite.ErrorContains(err, "container check must not be privileged")
+
+	probes, err := parseActionProbes(`[{"name": "job", "job": {"namespace": "app", "spec": {"template": {"spec": {"containers": [{"name": "check"}]}}}}}]`)
+	suite.NoError(err)
+	suite.Len(probes, 1)
+}
+
+func (suite *RGControllerTestSuite) TestActionInProgressStrictWithMalformedAnnotation() {
+	actionName := "Failover_Local"
+	replicationGroup := suite.createRGInActionInProgressState("", actionName, false, false)
+	rg := new(repv1.DellCSIReplicationGroup)
+	req := suite.getTypicalReconcileRequest(replicationGroup.Name)
+	err := suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err, "No error on RG Get")
+	rg.Annotations[Action] = `{"name": "FAILOVER_LOCAL", "completed": fals}`
+	err = suite.client.Update(context.Background(), rg)
+	suite.NoError(err, "No error on RG update")
+
+	suite.rgReconcile.Strict = true
+	_, err = suite.rgReconcile.Reconcile(context.Background(), req)
+	suite.NoError(err, "No error on RG reconcile")
+
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err, "No error on RG Get")
+	suite.Equal(ActionType(actionName).getInProgressState(), rg.Status.State, "State is unchanged")
+	suite.Equal(`{"name": "FAILOVER_LOCAL", "completed": fals}`, rg.Annotations[Action], "Annotation is not overwritten")
+	suite.Equal(controllers.DegradedCondition, rg.Status.Conditions[0].Condition)
+	suite.Contains(rg.Status.Conditions[0].ErrorMessage, "malformed")
+
+	// Degraded condition isn't repeated
+	_, err = suite.rgReconcile.Reconcile(context.Background(), req)
+	suite.NoError(err, "No error on RG reconcile")
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err, "No error on RG Get")
+	suite.Len(rg.Status.Conditions, 1)
+}
+
+type fakeEmitter struct {
+	events []string
+	data   []map[string]string
+}
+
+func (e *fakeEmitter) Emit(eventType string, _ *repv1.DellCSIReplicationGroup, data map[string]string) {
+	e.events = append(e.events, eventType)
+	e.data = append(e.data, data)
+}
+
+func (suite *RGControllerTestSuite) TestActionInProgressEmitsCloudEvent() {
+	actionName := "Failover_Local"
+	replicationGroup := suite.createRGInActionInProgressState("", actionName, false, false)
+	emitter := &fakeEmitter{}
+	suite.rgReconcile.EventEmitter = emitter
+	suite.repClient.SetCondition(csireplication.ExecuteActionWithSwap)
+
+	_, err := suite.rgReconcile.Reconcile(context.Background(), suite.getTypicalReconcileRequest(replicationGroup.Name))
+	suite.NoError(err, "No error on RG reconcile")
+
+	suite.Equal([]string{events.TypeRGActionCompleted}, emitter.events)
+	suite.Equal(ActionType(actionName).String(), emitter.data[0]["action"])
+	suite.Equal("succeeded", emitter.data[0]["result"])
+}
@@ -161,6 +161,7 @@ func updateRGLinkState(rg *repv1.DellCSIReplicationGroup, status string, isSourc
 		ErrorMessage:         errorMsg,
 		IsSource:             isSource,
 	}
+	rg.Status.LinkOptions = getLinkOptions(rg)
 }
 
 func updateRGLinkStatus(ctx context.Context, client client.Client, rg *repv1.DellCSIReplicationGroup, status string,
@@ -24,6 +24,7 @@ import (
 	controller "github.com/dell/csm-replication/controllers"
 	"github.com/dell/csm-replication/pkg/common"
 	csireplication "github.com/dell/csm-replication/pkg/csi-clients/replication"
+	"github.com/dell/csm-replication/pkg/events"
 	"github.com/dell/dell-csi-extensions/replication"
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -53,6 +54,12 @@ type PersistentVolumeReconciler struct {
 	SingleFlightGroup singleflight.Group
 	Domain            string
 	ClusterUID        string
+	// Strict fails the protection of volumes with malformed replication parameters instead of using the defaults
+	Strict bool
+	// EventEmitter emits the lifecycle events of the RGs, disabled if nil
+	EventEmitter events.Emitter
+	// PoolPlacer places the replica volumes in the pools of the target array configured in the storage class
+	PoolPlacer *PoolPlacer
 }
 
 const protectionIndexKey = "protection_id"
@@ -86,11 +93,36 @@ func (r *PersistentVolumeReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, nil
 	}
 
+	// Volumes which already joined a protection group keep being replicated
+	if _, ok := pv.Annotations[controller.RemoteVolumeAnnotation]; !ok {
+		if _, ok := pv.Annotations[controller.ReplicationGroup]; !ok {
+			excluded, err := r.isGenericEphemeralVolume(ctx, pv)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if excluded {
+				log.V(common.InfoLevel).Info("PV of a generic ephemeral volume, excluding it from replication")
+				return ctrl.Result{}, nil
+			}
+		}
+	}
+
+	if _, ok := pv.Annotations[controller.ReplicationGroup]; !ok && r.Strict {
+		if err := validateReplicationParams(storageClass.Parameters); err != nil {
+			// Retrying won't help until the storage class is fixed
+			return ctrl.Result{}, r.reportDegraded(ctx, pv, storageClass.Name, err)
+		}
+	}
+
 	var buffer []byte
 	isPVUpdated := false
 	if _, ok := pv.Annotations[controller.CreatedBy]; !ok {
 		if _, ok = pv.Annotations[controller.RemoteVolumeAnnotation]; !ok {
-			res, err := r.ReplicationClient.CreateRemoteVolume(ctx, pv.Spec.CSI.VolumeHandle, storageClass.Parameters)
+			remoteParams, err := r.getRemoteVolumeParams(ctx, pv, storageClass)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			res, err := r.ReplicationClient.CreateRemoteVolume(ctx, pv.Spec.CSI.VolumeHandle, remoteParams)
 			if err != nil {
 				log.Error(err, "Failed to create the remote volume", "VolumeHandle", pv.Spec.CSI.VolumeHandle)
 				return ctrl.Result{}, err
@@ -120,7 +152,7 @@ func (r *PersistentVolumeReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			log.V(common.DebugLevel).Info("This PV was created by sync controller. It is expected to have RG annotation. Re-queuing..")
 			return ctrl.Result{Requeue: true, RequeueAfter: controller.DefaultRetryInterval}, nil
 		}
-		if replicationGroupName, err = r.createProtectionGroupAndRG(ctx, pv.Spec.CSI.VolumeHandle, storageClass.Parameters); err != nil {
+		if replicationGroupName, err = r.createProtectionGroupAndRG(ctx, pv, storageClass.Parameters); err != nil {
 			return ctrl.Result{}, err
 		}
 
@@ -172,6 +204,76 @@ func (r *PersistentVolumeReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	return ctrl.Result{}, nil
 }
 
+// getRemoteVolumeParams returns the parameters of the replica volume of the PV, selecting the pool of the target array
+// it's placed in by the pool placement of the storage class. The pool is persisted in the RemotePool annotation of the PV
+// before the replica volume is created, so that a retry creates it in the same pool instead of selecting another one
+func (r *PersistentVolumeReconciler) getRemoteVolumeParams(ctx context.Context, pv *v1.PersistentVolume,
+	storageClass *storageV1.StorageClass,
+) (map[string]string, error) {
+	log := common.GetLoggerFromContext(ctx)
+	placement, err := getPoolPlacement(storageClass.Parameters)
+	if err != nil {
+		// Malformed placement is rejected by the strict mode, otherwise the default pool of the driver is used
+		log.Error(err, "Invalid pool placement, using the default pool", "StorageClassName", storageClass.Name)
+		r.EventRecorder.Eventf(pv, v1.EventTypeWarning, "InvalidPoolPlacement",
+			"Storage class %s has invalid pool placement, using the default pool: %s", storageClass.Name, err.Error())
+		return storageClass.Parameters, nil
+	}
+	if placement == nil || r.PoolPlacer == nil {
+		return storageClass.Parameters, nil
+	}
+	pool, ok := pv.Annotations[controller.RemotePool]
+	if !ok {
+		if value, ok := storageClass.Annotations[controller.RemotePoolCapacity]; ok && placement.Policy == controller.PlacementMostFree {
+			if placement.Capacity, err = controller.ParsePoolCapacity(value); err != nil {
+				log.Error(err, "Invalid capacity of the pools, using round-robin placement", "StorageClassName", storageClass.Name)
+			}
+		}
+		pool = r.PoolPlacer.Select(placement, storageClass.Name, pv)
+		if pool == "" {
+			return storageClass.Parameters, nil
+		}
+		controller.AddAnnotation(pv, controller.RemotePool, pool)
+		if err := r.Update(ctx, pv); err != nil {
+			log.Error(err, "Failed to add the remote pool annotation to the PV", "pool", pool)
+			return nil, err
+		}
+	}
+	log.V(common.InfoLevel).Info("Placing the replica volume", "policy", placement.Policy, "pool", pool)
+	return withRemotePool(storageClass.Parameters, placement.Parameter, pool), nil
+}
+
+// validateReplicationParams returns an error listing the malformed replication parameters of the storage class
+func validateReplicationParams(scParams map[string]string) error {
+	var msgs []string
+	for _, key := range []string{controller.RemotePVRetentionPolicy, controller.RemoteRGRetentionPolicy} {
+		if value, ok := scParams[key]; ok {
+			if err := controller.ValidateRetentionPolicy(value); err != nil {
+				msgs = append(msgs, fmt.Sprintf("%s: %s", key, err.Error()))
+			}
+		}
+	}
+	for _, key := range []string{controller.LinkCompression, controller.LinkEncryption} {
+		if value, ok := scParams[key]; ok {
+			if _, err := controller.NormalizeLinkOption(value); err != nil {
+				msgs = append(msgs, fmt.Sprintf("%s: %s", key, err.Error()))
+			}
+		}
+	}
+	if value, ok := scParams[controller.ActionProbes]; ok {
+		if _, err := parseActionProbes(value); err != nil {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", controller.ActionProbes, err.Error()))
+		}
+	}
+	if _, err := getPoolPlacement(scParams); err != nil {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", controller.RemotePoolPlacement, err.Error()))
+	}
+	if len(msgs) != 0 {
+		return fmt.Errorf("%s", strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
 func (r *PersistentVolumeReconciler) processVolumeForReplicationGroup(ctx context.Context, volume *v1.PersistentVolume,
 	replicationGroupName string,
 	scParams map[string]string,
@@ -239,8 +341,9 @@ func (r *PersistentVolumeReconciler) processVolumeForReplicationGroup(ctx contex
 	return nil
 }
 
-func (r *PersistentVolumeReconciler) createProtectionGroupAndRG(ctx context.Context, volumeHandle string, scParams map[string]string) (string, error) {
+func (r *PersistentVolumeReconciler) createProtectionGroupAndRG(ctx context.Context, pv *v1.PersistentVolume, scParams map[string]string) (string, error) {
 	log := common.GetLoggerFromContext(ctx)
+	volumeHandle := pv.Spec.CSI.VolumeHandle
 	log.V(common.InfoLevel).Info("Creating protection-group and RG")
 
 	if r.ClusterUID != "" {
@@ -248,6 +351,23 @@ func (r *PersistentVolumeReconciler) createProtectionGroupAndRG(ctx context.Cont
 		scParams[controller.ClusterUID] = r.ClusterUID
 	}
 
+	// Normalize the replication link options, so that the driver always receives either enabled or disabled
+	var invalidLinkOptions []string
+	for _, key := range []string{controller.LinkCompression, controller.LinkEncryption} {
+		value, ok := scParams[key]
+		if !ok {
+			continue
+		}
+		linkOption, err := controller.NormalizeLinkOption(value)
+		if err != nil {
+			log.Error(err, "Ignoring invalid replication link option", "key", key)
+			invalidLinkOptions = append(invalidLinkOptions, fmt.Sprintf("%s=%q", key, value))
+			delete(scParams, key)
+			continue
+		}
+		scParams[key] = linkOption
+	}
+
 	res, err := r.ReplicationClient.CreateStorageProtectionGroup(ctx, volumeHandle, scParams)
 	if err != nil {
 		log.Error(err, "Failed to create protection group", "volumeHandle", volumeHandle)
@@ -269,7 +389,7 @@ func (r *PersistentVolumeReconciler) createProtectionGroupAndRG(ctx context.Cont
 		// DellCSIReplicationGroup instance doesn't exists for the ProtectionGroup;
 		// creating a new one
 		var err error
-		replicationGroup, err = r.createReplicationGroupOnce(ctx, res, scParams[controller.StorageClassRemoteClusterParam], scParams[controller.RemoteRGRetentionPolicy])
+		replicationGroup, err = r.createReplicationGroupOnce(ctx, res, scParams)
 		if err != nil {
 			return "", err
 		}
@@ -279,12 +399,20 @@ func (r *PersistentVolumeReconciler) createProtectionGroupAndRG(ctx context.Cont
 		log.V(common.InfoLevel).Info("DellCSIReplicationGroup instance already exists for the protection group of this PV", "DellCSIReplicationGroupName", replicationGroup.Name)
 	}
 
+	// The protection group has been created without the invalid link options
+	for _, option := range invalidLinkOptions {
+		for _, obj := range []runtime.Object{pv, replicationGroup} {
+			r.EventRecorder.Eventf(obj, v1.EventTypeWarning, "InvalidLinkOption",
+				"Ignored invalid replication link option %s of storage class %s", option, pv.Spec.StorageClassName)
+		}
+	}
+
 	return replicationGroup.Name, nil
 }
 
-func (r *PersistentVolumeReconciler) createReplicationGroupOnce(ctx context.Context, res *replication.CreateStorageProtectionGroupResponse, remoteClusterID string, remoteRGRetentionPolicy string) (*repv1.DellCSIReplicationGroup, error) {
+func (r *PersistentVolumeReconciler) createReplicationGroupOnce(ctx context.Context, res *replication.CreateStorageProtectionGroupResponse, scParams map[string]string) (*repv1.DellCSIReplicationGroup, error) {
 	rgObj, err, _ := r.SingleFlightGroup.Do(res.GetLocalProtectionGroupId(), func() (interface{}, error) {
-		return r.createReplicationGroup(ctx, res, remoteClusterID, remoteRGRetentionPolicy)
+		return r.createReplicationGroup(ctx, res, scParams)
 	})
 	if err != nil {
 		return nil, err
@@ -292,10 +420,13 @@ func (r *PersistentVolumeReconciler) createReplicationGroupOnce(ctx context.Cont
 	return rgObj.(*repv1.DellCSIReplicationGroup), nil
 }
 
-func (r *PersistentVolumeReconciler) createReplicationGroup(ctx context.Context, res *replication.CreateStorageProtectionGroupResponse, remoteClusterID string, remoteRGRetentionPolicy string) (*repv1.DellCSIReplicationGroup, error) {
+func (r *PersistentVolumeReconciler) createReplicationGroup(ctx context.Context, res *replication.CreateStorageProtectionGroupResponse, scParams map[string]string) (*repv1.DellCSIReplicationGroup, error) {
 	log := common.GetLoggerFromContext(ctx)
 	log.V(common.InfoLevel).Info("Creating replication-group")
 
+	remoteClusterID := scParams[controller.StorageClassRemoteClusterParam]
+	remoteRGRetentionPolicy := scParams[controller.RemoteRGRetentionPolicy]
+
 	annotations := make(map[string]string)
 	labels := make(map[string]string)
 	labels[controller.RemoteClusterID] = remoteClusterID
@@ -324,6 +455,17 @@ func (r *PersistentVolumeReconciler) createReplicationGroup(ctx context.Context,
 		annotations[controller.RemoteRGRetentionPolicy] = controller.RemoteRetentionValueRetain
 	}
 
+	// Record the requested replication link options, so that they can be
+	// surfaced in the status even if the driver doesn't report them back
+	for _, key := range []string{controller.LinkCompression, controller.LinkEncryption} {
+		if value, ok := scParams[key]; ok {
+			annotations[key] = value
+		}
+	}
+	if probes, ok := scParams[controller.ActionProbes]; ok {
+		annotations[controller.ActionProbes] = probes
+	}
+
 	replicationGroup := &repv1.DellCSIReplicationGroup{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        "rg-" + uuid.New().String(),
@@ -346,9 +488,49 @@ func (r *PersistentVolumeReconciler) createReplicationGroup(ctx context.Context,
 		return nil, err
 	}
 	log.V(common.InfoLevel).Info("DellCSIReplicationGroup instance created for the protection group of the PV", "DellCSIReplicationGroupName", replicationGroup.Name)
+	events.Emit(r.EventEmitter, events.TypeRGCreated, replicationGroup, nil)
 	return replicationGroup, nil
 }
 
+// reportDegraded raises an event for the PV & adds a Degraded condition to its claim for the malformed replication
+// parameters of its storage class. Returns an error only if the condition couldn't be added
+func (r *PersistentVolumeReconciler) reportDegraded(ctx context.Context, pv *v1.PersistentVolume, scName string, err error) error {
+	log := common.GetLoggerFromContext(ctx)
+	log.Error(err, "Malformed replication parameters in the storage class", "StorageClassName", scName)
+	message := fmt.Sprintf("Storage class %s has malformed replication parameters: %s", scName, err.Error())
+	r.EventRecorder.Event(pv, v1.EventTypeWarning, controller.DegradedCondition, message)
+
+	if pv.Spec.ClaimRef == nil {
+		return nil
+	}
+	claim := new(v1.PersistentVolumeClaim)
+	err = r.Get(ctx, client.ObjectKey{Namespace: pv.Spec.ClaimRef.Namespace, Name: pv.Spec.ClaimRef.Name}, claim)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if claim.UID != pv.Spec.ClaimRef.UID || !controller.SetClaimDegradedCondition(claim, controller.ReasonMalformedReplicationParameters, message) {
+		return nil
+	}
+	if err := r.Status().Update(ctx, claim); err != nil {
+		log.Error(err, "Failed to add the Degraded condition to the PVC")
+		return err
+	}
+	return nil
+}
+
+// isGenericEphemeralVolume returns true if the PV is bound to the claim of a generic ephemeral volume
+func (r *PersistentVolumeReconciler) isGenericEphemeralVolume(ctx context.Context, pv *v1.PersistentVolume) (bool, error) {
+	if pv.Spec.ClaimRef == nil || pv.Annotations[controller.CreatedBy] != "" {
+		return false, nil
+	}
+	claim := new(v1.PersistentVolumeClaim)
+	err := r.Get(ctx, client.ObjectKey{Namespace: pv.Spec.ClaimRef.Namespace, Name: pv.Spec.ClaimRef.Name}, claim)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return claim.UID == pv.Spec.ClaimRef.UID && controller.IsGenericEphemeral(claim), nil
+}
+
 // SetupWithManager start using reconciler by creating new controller managed by provided manager
 func (r *PersistentVolumeReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, limiter workqueue.TypedRateLimiter[reconcile.Request], maxReconcilers int) error {
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &repv1.DellCSIReplicationGroup{}, protectionIndexKey, getProtectionGroupID); err != nil {
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"path"
 	"testing"
+	"time"
 
 	repv1 "github.com/dell/csm-replication/api/v1"
 	"github.com/dell/csm-replication/controllers"
@@ -28,6 +29,8 @@ import (
 	"github.com/stretchr/testify/suite"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -198,6 +201,310 @@ func (suite *PersistentVolumeControllerTestSuite) TestRGAdd() {
 	suite.Equal(rgName, newRGName, "PV should be added to same RG")
 }
 
+func (suite *PersistentVolumeControllerTestSuite) TestPVReconcileWithLinkOptions() {
+	ctx := context.Background()
+	sc := new(storagev1.StorageClass)
+	err := suite.client.Get(ctx, types.NamespacedName{Name: suite.driver.StorageClass}, sc)
+	suite.NoError(err)
+	sc.Parameters[controllers.LinkEncryption] = "true"
+	sc.Parameters[controllers.LinkCompression] = "invalid"
+	err = suite.client.Update(ctx, sc)
+	suite.NoError(err)
+
+	pvName := utils.FakePVName
+	pvObj := suite.getFakePV(pvName)
+	err = suite.client.Create(ctx, pvObj)
+	suite.NoError(err)
+
+	req := suite.getTypicalReconcileRequest(pvName)
+	_, err = suite.reconciler.Reconcile(ctx, req)
+	suite.NoError(err, "No error on PV reconcile")
+
+	updatedPV := new(corev1.PersistentVolume)
+	err = suite.client.Get(ctx, req.NamespacedName, updatedPV)
+	suite.NoError(err)
+
+	var rg repv1.DellCSIReplicationGroup
+	err = suite.client.Get(ctx, types.NamespacedName{Name: updatedPV.Annotations[controllers.ReplicationGroup]}, &rg)
+	suite.NoError(err)
+	suite.Equal(controllers.LinkOptionEnabled, rg.Annotations[controllers.LinkEncryption], "Encryption should be normalized")
+	_, ok := rg.Annotations[controllers.LinkCompression]
+	suite.False(ok, "Invalid compression value should be ignored")
+
+	linkOptions := getLinkOptions(&rg)
+	suite.Equal(controllers.LinkOptionEnabled, linkOptions.Encryption)
+	suite.False(linkOptions.CompressionReportedByDriver)
+	suite.False(linkOptions.EncryptionReportedByDriver)
+
+	rg.Spec.ProtectionGroupAttributes[controllers.LinkCompression] = controllers.LinkOptionDisabled
+	linkOptions = getLinkOptions(&rg)
+	suite.Equal(controllers.LinkOptionDisabled, linkOptions.Compression)
+	suite.True(linkOptions.CompressionReportedByDriver)
+	suite.False(linkOptions.EncryptionReportedByDriver, "Only the compression is confirmed by the driver")
+
+	events := suite.reconciler.EventRecorder.(*record.FakeRecorder).Events
+	suite.Contains(<-events, "InvalidLinkOption")
+}
+
+func (suite *PersistentVolumeControllerTestSuite) TestPVReconcileStrictWithMalformedParams() {
+	ctx := context.Background()
+	sc := new(storagev1.StorageClass)
+	err := suite.client.Get(ctx, types.NamespacedName{Name: suite.driver.StorageClass}, sc)
+	suite.NoError(err)
+	sc.Parameters[controllers.RemotePVRetentionPolicy] = "retian"
+	err = suite.client.Update(ctx, sc)
+	suite.NoError(err)
+
+	claim := utils.GetPVCObj(utils.PVCName, "default", suite.driver.StorageClass)
+	claim.UID = "claim-uid"
+	err = suite.client.Create(ctx, claim)
+	suite.NoError(err)
+
+	pvName := utils.FakePVName
+	pvObj := suite.getFakePV(pvName)
+	pvObj.Spec.ClaimRef = &corev1.ObjectReference{Namespace: claim.Namespace, Name: claim.Name, UID: claim.UID}
+	err = suite.client.Create(ctx, pvObj)
+	suite.NoError(err)
+
+	suite.reconciler.Strict = true
+	req := suite.getTypicalReconcileRequest(pvName)
+	res, err := suite.reconciler.Reconcile(ctx, req)
+	suite.NoError(err, "Malformed parameters are not retried")
+	suite.Equal(ctrl.Result{}, res)
+
+	events := suite.reconciler.EventRecorder.(*record.FakeRecorder).Events
+	suite.Contains(<-events, "invalid retention policy value: retian")
+	updatedClaim := new(corev1.PersistentVolumeClaim)
+	err = suite.client.Get(ctx, types.NamespacedName{Namespace: claim.Namespace, Name: claim.Name}, updatedClaim)
+	suite.NoError(err)
+	suite.Len(updatedClaim.Status.Conditions, 1)
+	suite.Equal(corev1.PersistentVolumeClaimConditionType(controllers.DegradedCondition), updatedClaim.Status.Conditions[0].Type)
+	suite.Equal(controllers.ReasonMalformedReplicationParameters, updatedClaim.Status.Conditions[0].Reason)
+
+	updatedPV := new(corev1.PersistentVolume)
+	err = suite.client.Get(ctx, req.NamespacedName, updatedPV)
+	suite.NoError(err)
+	_, ok := updatedPV.Annotations[controllers.ReplicationGroup]
+	suite.False(ok, "PV should not be protected")
+
+	// Without strict mode the retention policy falls back to retain
+	suite.reconciler.Strict = false
+	_, err = suite.reconciler.Reconcile(ctx, req)
+	suite.NoError(err, "No error on PV reconcile")
+	err = suite.client.Get(ctx, req.NamespacedName, updatedPV)
+	suite.NoError(err)
+	suite.Equal(controllers.RemoteRetentionValueRetain, updatedPV.Annotations[controllers.RemotePVRetentionPolicy])
+}
+
+func (suite *PersistentVolumeControllerTestSuite) TestPVReconcileWithPoolPlacement() {
+	ctx := context.Background()
+	sc := new(storagev1.StorageClass)
+	err := suite.client.Get(ctx, types.NamespacedName{Name: suite.driver.StorageClass}, sc)
+	suite.NoError(err)
+	sc.Parameters[controllers.RemotePoolPlacement] = controllers.PlacementPinned
+	sc.Parameters[controllers.RemotePoolPins] = "*=pool-b"
+	sc.Parameters[controllers.RemotePoolParameter] = "storagepool"
+	err = suite.client.Update(ctx, sc)
+	suite.NoError(err)
+
+	pvName := utils.FakePVName
+	pvObj := suite.getFakePV(pvName)
+	err = suite.client.Create(ctx, pvObj)
+	suite.NoError(err)
+
+	suite.reconciler.PoolPlacer = &PoolPlacer{}
+	req := suite.getTypicalReconcileRequest(pvName)
+	_, err = suite.reconciler.Reconcile(ctx, req)
+	suite.NoError(err, "No error on PV reconcile")
+
+	updatedPV := new(corev1.PersistentVolume)
+	err = suite.client.Get(ctx, req.NamespacedName, updatedPV)
+	suite.NoError(err)
+	suite.Equal("pool-b", updatedPV.Annotations[controllers.RemotePool], "Replica volume is placed in the pinned pool")
+}
+
+func (suite *PersistentVolumeControllerTestSuite) TestPVReconcileWithMostFreePlacement() {
+	ctx := context.Background()
+	sc := new(storagev1.StorageClass)
+	err := suite.client.Get(ctx, types.NamespacedName{Name: suite.driver.StorageClass}, sc)
+	suite.NoError(err)
+	sc.Parameters[controllers.RemotePoolPlacement] = controllers.PlacementMostFree
+	sc.Parameters[controllers.RemotePools] = "pool-a,pool-b"
+	sc.Parameters[controllers.RemotePoolParameter] = "storagepool"
+	// Capacity of the target pools published by the replication-controller
+	sc.Annotations = map[string]string{
+		controllers.RemotePoolCapacity: fmt.Sprintf(`{"timestamp":%q,"validUntil":%q,"pools":{"pool-a":10,"pool-b":20}}`,
+			time.Now().Format(time.RFC3339), time.Now().Add(time.Hour).Format(time.RFC3339)),
+	}
+	err = suite.client.Update(ctx, sc)
+	suite.NoError(err)
+
+	pvName := utils.FakePVName
+	pvObj := suite.getFakePV(pvName)
+	err = suite.client.Create(ctx, pvObj)
+	suite.NoError(err)
+
+	suite.reconciler.PoolPlacer = &PoolPlacer{}
+	req := suite.getTypicalReconcileRequest(pvName)
+	_, err = suite.reconciler.Reconcile(ctx, req)
+	suite.NoError(err, "No error on PV reconcile")
+
+	updatedPV := new(corev1.PersistentVolume)
+	err = suite.client.Get(ctx, req.NamespacedName, updatedPV)
+	suite.NoError(err)
+	suite.Equal("pool-b", updatedPV.Annotations[controllers.RemotePool], "Replica volume is placed in the most free pool")
+}
+
+func (suite *PersistentVolumeControllerTestSuite) TestPVReconcileReusesRemotePool() {
+	ctx := context.Background()
+	sc := new(storagev1.StorageClass)
+	err := suite.client.Get(ctx, types.NamespacedName{Name: suite.driver.StorageClass}, sc)
+	suite.NoError(err)
+	sc.Parameters[controllers.RemotePoolPlacement] = controllers.PlacementRoundRobin
+	sc.Parameters[controllers.RemotePools] = "pool-a,pool-b"
+	sc.Parameters[controllers.RemotePoolParameter] = "storagepool"
+	err = suite.client.Update(ctx, sc)
+	suite.NoError(err)
+
+	// The pool selected by a previous attempt to create the replica volume
+	pvName := utils.FakePVName
+	pvObj := suite.getFakePV(pvName)
+	pvObj.Annotations = map[string]string{controllers.RemotePool: "pool-b"}
+	err = suite.client.Create(ctx, pvObj)
+	suite.NoError(err)
+
+	placer := &PoolPlacer{}
+	suite.reconciler.PoolPlacer = placer
+	req := suite.getTypicalReconcileRequest(pvName)
+	_, err = suite.reconciler.Reconcile(ctx, req)
+	suite.NoError(err, "No error on PV reconcile")
+
+	updatedPV := new(corev1.PersistentVolume)
+	err = suite.client.Get(ctx, req.NamespacedName, updatedPV)
+	suite.NoError(err)
+	suite.Equal("pool-b", updatedPV.Annotations[controllers.RemotePool], "Replica volume is placed in the persisted pool")
+	suite.Contains(updatedPV.Annotations, controllers.RemoteVolumeAnnotation)
+
+	placement, err := getPoolPlacement(sc.Parameters)
+	suite.NoError(err)
+	suite.Equal("pool-a", placer.Select(placement, sc.Name, updatedPV), "Round-robin placement isn't advanced by the retry")
+}
+
+func (suite *PersistentVolumeControllerTestSuite) TestPVReconcileGenericEphemeral() {
+	ctx := context.Background()
+	isController := true
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-pod-scratch",
+			Namespace: "fake-ns",
+			UID:       "fake-claim-uid",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "v1", Kind: "Pod", Name: "fake-pod", UID: "fake-pod-uid", Controller: &isController},
+			},
+		},
+	}
+	err := suite.client.Create(ctx, claim)
+	suite.NoError(err)
+
+	pvName := utils.FakePVName
+	pvObj := suite.getFakePV(pvName)
+	pvObj.Spec.ClaimRef = &corev1.ObjectReference{Namespace: claim.Namespace, Name: claim.Name, UID: claim.UID}
+	err = suite.client.Create(ctx, pvObj)
+	suite.NoError(err)
+
+	req := suite.getTypicalReconcileRequest(pvName)
+	_, err = suite.reconciler.Reconcile(ctx, req)
+	suite.NoError(err, "No error on PV reconcile")
+
+	updatedPV := new(corev1.PersistentVolume)
+	err = suite.client.Get(ctx, req.NamespacedName, updatedPV)
+	suite.NoError(err)
+	suite.NotContains(updatedPV.Annotations, controllers.RemoteVolumeAnnotation, "No replica volume is created")
+	suite.NotContains(updatedPV.Annotations, controllers.ReplicationGroup, "PV doesn't join a protection group")
+}
+
+func (suite *PersistentVolumeControllerTestSuite) TestPoolPlacerSelect() {
+	pv := suite.getFakePV(utils.FakePVName)
+	pv.Spec.ClaimRef = &corev1.ObjectReference{Namespace: "app"}
+
+	suite.Run("round-robin", func() {
+		placement, err := getPoolPlacement(map[string]string{
+			controllers.RemotePoolPlacement: controllers.PlacementRoundRobin,
+			controllers.RemotePools:         "pool-a,pool-b",
+			controllers.RemotePoolParameter: "storagepool",
+		})
+		suite.NoError(err)
+		placer := &PoolPlacer{}
+		var pools []string
+		for i := 0; i < 3; i++ {
+			pools = append(pools, placer.Select(placement, "sc", pv))
+		}
+		suite.Equal([]string{"pool-a", "pool-b", "pool-a"}, pools)
+	})
+
+	suite.Run("pinned", func() {
+		placement, err := getPoolPlacement(map[string]string{
+			controllers.RemotePoolPlacement: controllers.PlacementPinned,
+			controllers.RemotePoolPins:      "app=pool-a, *=pool-b",
+			controllers.RemotePoolParameter: "storagepool",
+		})
+		suite.NoError(err)
+		suite.Equal("pool-a", (&PoolPlacer{}).Select(placement, "sc", pv))
+
+		pv.Spec.ClaimRef.Namespace = "other"
+		suite.Equal("pool-b", (&PoolPlacer{}).Select(placement, "sc", pv))
+	})
+
+	suite.Run("most-free", func() {
+		placement, err := getPoolPlacement(map[string]string{
+			controllers.RemotePoolPlacement: controllers.PlacementMostFree,
+			controllers.RemotePools:         "pool-a,pool-b,pool-c",
+			controllers.RemotePoolParameter: "storagepool",
+		})
+		suite.NoError(err)
+		placer := &PoolPlacer{}
+
+		// Round-robin placement until the capacity is published
+		suite.Equal("pool-a", placer.Select(placement, "sc", pv))
+
+		// The volumes placed since the capacity was published are deducted from it
+		pv.Spec.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}
+		placement.Capacity = &controllers.PoolCapacity{
+			Timestamp:  metav1.Now(),
+			ValidUntil: metav1.NewTime(time.Now().Add(time.Minute)),
+			Pools:      map[string]int64{"pool-a": 15 << 30, "pool-b": 25 << 30},
+		}
+		var pools []string
+		for i := 0; i < 3; i++ {
+			pools = append(pools, placer.Select(placement, "sc", pv))
+		}
+		suite.Equal([]string{"pool-b", "pool-a", "pool-b"}, pools)
+
+		// Stale capacity isn't used
+		placement.Capacity.ValidUntil = metav1.NewTime(time.Now().Add(-time.Minute))
+		suite.Equal("pool-b", placer.Select(placement, "sc", pv))
+	})
+
+	suite.Run("invalid", func() {
+		_, err := getPoolPlacement(map[string]string{
+			controllers.RemotePoolPlacement: "random",
+			controllers.RemotePoolParameter: "storagepool",
+		})
+		suite.Error(err)
+		_, err = getPoolPlacement(map[string]string{
+			controllers.RemotePoolPlacement: controllers.PlacementPinned,
+			controllers.RemotePoolPins:      "app",
+			controllers.RemotePoolParameter: "storagepool",
+		})
+		suite.Error(err)
+		_, err = getPoolPlacement(map[string]string{
+			controllers.RemotePoolPlacement: controllers.PlacementRoundRobin,
+			controllers.RemotePools:         "pool-a",
+		})
+		suite.Error(err)
+	})
+}
+
 func (suite *PersistentVolumeControllerTestSuite) TestPVReconcileDifferentDriver() {
 	// Create SC with a different driver
 	otherDriver := "some.other.driver"
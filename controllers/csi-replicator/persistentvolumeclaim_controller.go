@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	reconciler "sigs.k8s.io/controller-runtime/pkg/controller"
 
@@ -113,6 +114,10 @@ func (r *PersistentVolumeClaimReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, nil
 	}
 
+	if controller.IsGenericEphemeral(claim) {
+		return ctrl.Result{}, r.excludeGenericEphemeralClaim(ctx, claim)
+	}
+
 	log.V(common.DebugLevel).Info("Checking for the PVC state")
 
 	if claim.Status.Phase != v1.ClaimBound {
@@ -176,6 +181,26 @@ func (r *PersistentVolumeClaimReconciler) Reconcile(ctx context.Context, req ctr
 	return ctrl.Result{}, nil
 }
 
+// excludeGenericEphemeralClaim reports the exclusion of the claim of a generic ephemeral volume from replication.
+// The claim is deleted with its pod, so the replication pair of its volume would be left behind on the arrays
+func (r *PersistentVolumeClaimReconciler) excludeGenericEphemeralClaim(ctx context.Context, claim *v1.PersistentVolumeClaim) error {
+	log := common.GetLoggerFromContext(ctx)
+	log.V(common.InfoLevel).Info("PVC of a generic ephemeral volume, excluding it from replication")
+
+	claimCopy := claim.DeepCopy()
+	if !controller.SetReplicationExcludedCondition(claimCopy, controller.ReasonGenericEphemeralVolume,
+		"Generic ephemeral volumes are not replicated") {
+		return nil
+	}
+	r.EventRecorder.Eventf(claim, v1.EventTypeWarning, controller.ReplicationExcludedCondition,
+		"PVC of generic ephemeral volume of pod %s is not replicated", metav1.GetControllerOf(claim).Name)
+	if err := r.Status().Update(ctx, claimCopy); err != nil {
+		log.Error(err, "Failed to add the ReplicationExcluded condition to the PVC")
+		return err
+	}
+	return nil
+}
+
 func (r *PersistentVolumeClaimReconciler) processClaimForRemoteVolume(ctx context.Context, claim *v1.PersistentVolumeClaim,
 	pv *v1.PersistentVolume, scParams map[string]string, buffer string,
 ) error {
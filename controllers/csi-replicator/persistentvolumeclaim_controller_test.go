@@ -436,6 +436,45 @@ func (suite *PVControllerTestSuite) TestPVCReconciliation() {
 	utils.ValidateAnnotations(updatedPVC.ObjectMeta.Annotations, suite.T())
 }
 
+func (suite *PVControllerTestSuite) TestPVCReconciliationGenericEphemeral() {
+	// scenario: PVC of a generic ephemeral volume is excluded from replication
+	ctx := context.Background()
+	pvcObj := utils.GetPVCObj("fake-pod-scratch", suite.mockUtils.Specs.Namespace, suite.driver.StorageClass)
+	pvcObj.Status.Phase = corev1.ClaimBound
+	pvcObj.Spec.VolumeName = "fake-pv-ephemeral"
+	isController := true
+	pvcObj.OwnerReferences = []metav1.OwnerReference{
+		{APIVersion: "v1", Kind: "Pod", Name: "fake-pod", UID: "fake-pod-uid", Controller: &isController},
+	}
+	err := suite.mockUtils.FakeControllerClient.Create(ctx, pvcObj)
+	suite.NoError(err)
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: suite.mockUtils.Specs.Namespace,
+			Name:      "fake-pod-scratch",
+		},
+	}
+	res, err := PVCReconciler.Reconcile(context.Background(), req)
+	suite.NoError(err)
+	suite.Equal(false, res.Requeue, "PVC not waiting for the remote volume")
+
+	updatedPVC := new(corev1.PersistentVolumeClaim)
+	err = suite.mockUtils.FakeControllerClient.Get(ctx, req.NamespacedName, updatedPVC)
+	suite.NoError(err)
+	suite.NotContains(updatedPVC.Annotations, controllers.RemoteVolumeAnnotation)
+	suite.Len(updatedPVC.Status.Conditions, 1)
+	suite.Equal(corev1.PersistentVolumeClaimConditionType(controllers.ReplicationExcludedCondition), updatedPVC.Status.Conditions[0].Type)
+	suite.Equal(controllers.ReasonGenericEphemeralVolume, updatedPVC.Status.Conditions[0].Reason)
+
+	// The condition is only added once
+	_, err = PVCReconciler.Reconcile(context.Background(), req)
+	suite.NoError(err)
+	err = suite.mockUtils.FakeControllerClient.Get(ctx, req.NamespacedName, updatedPVC)
+	suite.NoError(err)
+	suite.Len(updatedPVC.Status.Conditions, 1)
+}
+
 func (suite *PVControllerTestSuite) TestPVCReconciliationWithEmptyRG() {
 	// scenario: Negative scenario when RG annotation is empty
 	ctx := context.Background()
@@ -0,0 +1,179 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package csireplicator
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dell/csm-replication/controllers"
+	v1 "k8s.io/api/core/v1"
+)
+
+// poolPinWildcard pins the pool to all the namespaces which aren't pinned explicitly
+const poolPinWildcard = "*"
+
+// PoolPlacement is the policy placing the replica volumes in the pools of the target array
+type PoolPlacement struct {
+	// Policy is one of round-robin, most-free or pinned
+	Policy string
+	// Pools candidate pools of the round-robin and most-free policies
+	Pools []string
+	// Pins pools pinned to the namespaces by the pinned policy
+	Pins map[string]string
+	// Parameter name of the driver parameter selecting the pool of the replica volume
+	Parameter string
+	// Capacity available capacity of the pools published on the storage class, used by the most-free policy
+	Capacity *controllers.PoolCapacity
+}
+
+// getPoolPlacement returns the pool placement configured in the storage class parameters, nil if not configured
+func getPoolPlacement(scParams map[string]string) (*PoolPlacement, error) {
+	policy, ok := scParams[controllers.RemotePoolPlacement]
+	if !ok {
+		return nil, nil
+	}
+	placement := &PoolPlacement{
+		Policy:    strings.ToLower(strings.TrimSpace(policy)),
+		Parameter: strings.TrimSpace(scParams[controllers.RemotePoolParameter]),
+		Pins:      make(map[string]string),
+	}
+	if placement.Parameter == "" {
+		return nil, fmt.Errorf("%s must be set", controllers.RemotePoolParameter)
+	}
+	placement.Pools = controllers.ParsePools(scParams[controllers.RemotePools])
+	for _, pin := range strings.Split(scParams[controllers.RemotePoolPins], ",") {
+		if pin = strings.TrimSpace(pin); pin == "" {
+			continue
+		}
+		namespace, pool, found := strings.Cut(pin, "=")
+		namespace, pool = strings.TrimSpace(namespace), strings.TrimSpace(pool)
+		if !found || namespace == "" || pool == "" {
+			return nil, fmt.Errorf("invalid pool pin %q, must be in the form namespace=pool", pin)
+		}
+		placement.Pins[namespace] = pool
+	}
+
+	switch placement.Policy {
+	case controllers.PlacementRoundRobin, controllers.PlacementMostFree:
+		if len(placement.Pools) == 0 {
+			return nil, fmt.Errorf("%s must list the pools of the %s placement", controllers.RemotePools, placement.Policy)
+		}
+	case controllers.PlacementPinned:
+		if len(placement.Pins) == 0 {
+			return nil, fmt.Errorf("%s must list the pools of the %s placement", controllers.RemotePoolPins, placement.Policy)
+		}
+	default:
+		return nil, fmt.Errorf("invalid placement policy %q, must be one of %s, %s, %s", policy,
+			controllers.PlacementRoundRobin, controllers.PlacementMostFree, controllers.PlacementPinned)
+	}
+	return placement, nil
+}
+
+// PoolPlacer selects the pools of the target array the replica volumes are created in
+type PoolPlacer struct {
+	lock   sync.Mutex
+	next   map[string]int
+	placed map[string]*placedCapacity
+}
+
+// placedCapacity is the capacity of the replica volumes placed by the most-free policy since the capacity was published
+type placedCapacity struct {
+	timestamp time.Time
+	pools     map[string]int64
+}
+
+// Select returns the pool the replica volume of the PV is placed in.
+// An empty pool means that the replica volume is placed in the default pool of the driver
+func (p *PoolPlacer) Select(placement *PoolPlacement, scName string, pv *v1.PersistentVolume) string {
+	switch placement.Policy {
+	case controllers.PlacementPinned:
+		namespace := ""
+		if pv.Spec.ClaimRef != nil {
+			namespace = pv.Spec.ClaimRef.Namespace
+		}
+		if pool, ok := placement.Pins[namespace]; ok {
+			return pool
+		}
+		return placement.Pins[poolPinWildcard]
+	case controllers.PlacementMostFree:
+		// Round-robin placement is used until the capacity of the pools is known
+		if pool := p.selectMostFree(placement, scName, pv); pool != "" {
+			return pool
+		}
+	}
+	return p.selectRoundRobin(scName, placement.Pools)
+}
+
+// selectRoundRobin returns the next pool of the storage class
+func (p *PoolPlacer) selectRoundRobin(scName string, pools []string) string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.next == nil {
+		p.next = make(map[string]int)
+	}
+	i := p.next[scName] % len(pools)
+	p.next[scName] = i + 1
+	return pools[i]
+}
+
+// selectMostFree returns the pool with the most available capacity, empty if the capacity of none of the pools is known.
+// The replica volumes placed since the capacity was published are deducted from it, so that they don't all land in the same pool
+func (p *PoolPlacer) selectMostFree(placement *PoolPlacement, scName string, pv *v1.PersistentVolume) string {
+	if placement.Capacity == nil || placement.Capacity.IsStale(time.Now()) {
+		return ""
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.placed == nil {
+		p.placed = make(map[string]*placedCapacity)
+	}
+	placed, ok := p.placed[scName]
+	if !ok || !placed.timestamp.Equal(placement.Capacity.Timestamp.Time) {
+		placed = &placedCapacity{timestamp: placement.Capacity.Timestamp.Time, pools: make(map[string]int64)}
+		p.placed[scName] = placed
+	}
+
+	selected := ""
+	var mostFree int64
+	for _, pool := range placement.Pools {
+		available, ok := placement.Capacity.Pools[pool]
+		if !ok {
+			continue
+		}
+		available -= placed.pools[pool]
+		if selected == "" || available > mostFree {
+			selected, mostFree = pool, available
+		}
+	}
+	if selected != "" {
+		if size, ok := pv.Spec.Capacity[v1.ResourceStorage]; ok {
+			placed.pools[selected] += size.Value()
+		}
+	}
+	return selected
+}
+
+// withRemotePool returns a copy of the storage class parameters selecting the pool
+func withRemotePool(scParams map[string]string, parameter, pool string) map[string]string {
+	params := make(map[string]string, len(scParams)+1)
+	for k, v := range scParams {
+		params[k] = v
+	}
+	params[parameter] = pool
+	return params
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 
+	repv1 "github.com/dell/csm-replication/api/v1"
 	controller "github.com/dell/csm-replication/controllers"
 	"github.com/dell/csm-replication/pkg/common"
 	storageV1 "k8s.io/api/storage/v1"
@@ -60,3 +61,22 @@ func shouldContinue(ctx context.Context, class *storageV1.StorageClass, driverNa
 	}
 	return true
 }
+
+// getLinkOptions returns the effective replication link options of the RG.
+// Values reported by the driver in the protection group attributes take precedence
+// over the values requested through the storage class
+func getLinkOptions(rg *repv1.DellCSIReplicationGroup) repv1.LinkOptions {
+	linkOptions := repv1.LinkOptions{
+		Compression: rg.Annotations[controller.LinkCompression],
+		Encryption:  rg.Annotations[controller.LinkEncryption],
+	}
+	if value, err := controller.NormalizeLinkOption(rg.Spec.ProtectionGroupAttributes[controller.LinkCompression]); err == nil {
+		linkOptions.Compression = value
+		linkOptions.CompressionReportedByDriver = true
+	}
+	if value, err := controller.NormalizeLinkOption(rg.Spec.ProtectionGroupAttributes[controller.LinkEncryption]); err == nil {
+		linkOptions.Encryption = value
+		linkOptions.EncryptionReportedByDriver = true
+	}
+	return linkOptions
+}
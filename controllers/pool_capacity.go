@@ -0,0 +1,59 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PoolCapacity is the available capacity of the pools of the target array, as published in the
+// RemotePoolCapacity annotation of the storage class by the replication-controller
+type PoolCapacity struct {
+	// Timestamp is the time the capacity has been read from the target cluster
+	Timestamp metav1.Time `json:"timestamp"`
+	// ValidUntil is the time after which the capacity is considered stale
+	ValidUntil metav1.Time `json:"validUntil"`
+	// Pools is the available capacity of the pools in bytes, pools with unknown capacity are omitted
+	Pools map[string]int64 `json:"pools"`
+}
+
+// IsStale returns true if the capacity is too old to be used for placing the replica volumes
+func (c *PoolCapacity) IsStale(now time.Time) bool {
+	return now.After(c.ValidUntil.Time)
+}
+
+// ParsePoolCapacity parses the value of the RemotePoolCapacity annotation
+func ParsePoolCapacity(value string) (*PoolCapacity, error) {
+	capacity := new(PoolCapacity)
+	if err := json.Unmarshal([]byte(value), capacity); err != nil {
+		return nil, err
+	}
+	return capacity, nil
+}
+
+// ParsePools returns the pools listed in the RemotePools parameter
+func ParsePools(value string) []string {
+	var pools []string
+	for _, pool := range strings.Split(value, ",") {
+		if pool = strings.TrimSpace(pool); pool != "" {
+			pools = append(pools, pool)
+		}
+	}
+	return pools
+}
@@ -28,6 +28,7 @@ import (
 	repv1 "github.com/dell/csm-replication/api/v1"
 	controller "github.com/dell/csm-replication/controllers"
 	"github.com/dell/csm-replication/pkg/connection"
+	"github.com/dell/csm-replication/pkg/events"
 	s1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -52,12 +53,15 @@ const (
 // ReplicationGroupReconciler reconciles a ReplicationGroup object
 type ReplicationGroupReconciler struct {
 	client.Client
-	Log                logr.Logger
-	Scheme             *runtime.Scheme
-	EventRecorder      record.EventRecorder
-	PVCRequeueInterval time.Duration
-	Config             connection.MultiClusterClient
-	Domain             string
+	Log           logr.Logger
+	Scheme        *runtime.Scheme
+	EventRecorder record.EventRecorder
+	Config        connection.MultiClusterClient
+	Domain        string
+	// Strict reports malformed annotations with a Degraded condition instead of falling back to the defaults
+	Strict bool
+	// EventEmitter emits the lifecycle events of the RGs, disabled if nil
+	EventEmitter events.Emitter
 }
 
 // +kubebuilder:rbac:groups=replication.storage.dell.com,resources=dellcsireplicationgroups,verbs=get;list;watch;update;patch;delete;create
@@ -68,6 +72,7 @@ type ReplicationGroupReconciler struct {
 func (r *ReplicationGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("dellcsireplicationgroup", req.Name)
 	ctx = context.WithValue(ctx, common.LoggerContextKey, log)
+	ctx = connection.WithFeature(ctx, connection.FeatureRGSync)
 
 	localRG := new(repv1.DellCSIReplicationGroup)
 	err := r.Get(ctx, req.NamespacedName, localRG)
@@ -107,6 +112,11 @@ func (r *ReplicationGroupReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	annotations[controller.RemoteReplicationGroup] = localRGName
 	annotations[controller.RemoteRGRetentionPolicy] = localRG.Annotations[controller.RemoteRGRetentionPolicy]
 	annotations[controller.RemoteClusterID] = localClusterID
+	for _, key := range []string{controller.LinkCompression, controller.LinkEncryption} {
+		if value, ok := localRG.Annotations[key]; ok {
+			annotations[key] = value
+		}
+	}
 
 	labels := make(map[string]string)
 
@@ -154,6 +164,16 @@ func (r *ReplicationGroupReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		log.Info(fmt.Sprintf("RetentionPolicy:found:%v,value-->%s", ok, retentionPolicy))
 		log.Info("Retention policy not set, using retain as the default policy")
 		retentionPolicy = controller.RemoteRetentionValueRetain // we will default to retain the RG if there is no retention policy is set
+	} else if err := controller.ValidateRetentionPolicy(retentionPolicy); err != nil && r.Strict {
+		err = fmt.Errorf("malformed %s annotation: %s", controller.RemoteRGRetentionPolicy, err.Error())
+		if localRG.DeletionTimestamp.IsZero() {
+			return ctrl.Result{}, r.reportDegraded(ctx, localRG, err)
+		}
+		// The malformed policy must not block the deletion, the remote RG is retained
+		log.Error(err, "Malformed annotation in strict mode, retaining the remote RG")
+		r.EventRecorder.Eventf(localRG, eventTypeWarning, controller.DegradedCondition,
+			"%s, retaining the remote RG", err.Error())
+		retentionPolicy = controller.RemoteRetentionValueRetain
 	}
 
 	// Handle RG deletion if timestamp is set
@@ -182,7 +202,7 @@ func (r *ReplicationGroupReconciler) Reconcile(ctx context.Context, req ctrl.Req
 						// Add annotation on the remote RG to request its deletion
 						remoteRGCopy := remoteRG.DeepCopy()
 						controller.AddAnnotation(remoteRGCopy, controller.DeletionRequested, "yes")
-						err := remoteClient.UpdateReplicationGroup(ctx, remoteRGCopy)
+						err := remoteClient.UpdateReplicationGroup(connection.WithFeature(ctx, connection.FeatureDeletion), remoteRGCopy)
 						if err != nil {
 							return ctrl.Result{}, err
 						}
@@ -218,6 +238,13 @@ func (r *ReplicationGroupReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, r.Delete(ctx, rgCopy)
 	}
 
+	// Rebuild the pairing annotations of a half-synced RG instead of creating another remote RG
+	if needsRepair(rgCopy) {
+		if updated, err := r.repairAnnotations(ctx, rgCopy, remoteClient, localClusterID); err != nil || updated {
+			return ctrl.Result{}, err
+		}
+	}
+
 	createRG := false
 
 	// If the RG already exists on the Remote Cluster,
@@ -303,8 +330,12 @@ func (r *ReplicationGroupReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 		controller.AddAnnotation(localRG, controller.RemoteReplicationGroup, remoteRGName)
 		controller.AddAnnotation(localRG, controller.RGSyncComplete, "yes")
-		err = r.Update(ctx, localRG)
-		return ctrl.Result{}, err
+		if err = r.Update(ctx, localRG); err != nil {
+			return ctrl.Result{}, err
+		}
+		events.Emit(r.EventEmitter, events.TypeRGSynced, localRG,
+			map[string]string{"remoteReplicationGroup": remoteRGName})
+		return ctrl.Result{}, nil
 	}
 
 	err = r.processLastActionResult(ctx, localRG, remoteClient, log)
@@ -352,6 +383,7 @@ func (r *ReplicationGroupReconciler) processLastActionResult(ctx context.Context
 
 func (r *ReplicationGroupReconciler) processSnapshotEvent(ctx context.Context, group *repv1.DellCSIReplicationGroup, remoteClient connection.RemoteClusterClient, log logr.Logger) error {
 	lastAction := group.Status.LastAction
+	ctx = connection.WithFeature(ctx, connection.FeatureSnapshotReplication)
 
 	val, ok := group.Annotations[csireplicator.Action]
 	if !ok {
@@ -363,11 +395,21 @@ func (r *ReplicationGroupReconciler) processSnapshotEvent(ctx context.Context, g
 	err := json.Unmarshal([]byte(val), &actionAnnotation)
 	if err != nil {
 		log.Error(err, "JSON unmarshal error", "actionAnnotation", actionAnnotation)
+		if r.Strict {
+			if err := r.reportDegraded(ctx, group, fmt.Errorf("malformed %s annotation: %s", csireplicator.Action, err.Error())); err != nil {
+				return err
+			}
+		}
 		return err
 	}
 
 	if _, err := remoteClient.GetSnapshotClass(ctx, actionAnnotation.SnapshotClass); err != nil {
 		log.Error(err, "Snapshot class does not exist on remote cluster. Not creating the remote snapshots.")
+		if r.Strict && errors.IsNotFound(err) {
+			if err := r.reportDegraded(ctx, group, fmt.Errorf("unknown snapshot class %q on the remote cluster", actionAnnotation.SnapshotClass)); err != nil {
+				return err
+			}
+		}
 		return err
 	}
 
@@ -408,6 +450,17 @@ func (r *ReplicationGroupReconciler) processSnapshotEvent(ctx context.Context, g
 	return nil
 }
 
+// reportDegraded raises an event & adds a Degraded condition to the RG for a malformed annotation.
+func (r *ReplicationGroupReconciler) reportDegraded(ctx context.Context, rg *repv1.DellCSIReplicationGroup, err error) error {
+	log := common.GetLoggerFromContext(ctx)
+	log.Error(err, "Malformed annotation in strict mode")
+	r.EventRecorder.Event(rg, eventTypeWarning, controller.DegradedCondition, err.Error())
+	if controller.SetDegradedCondition(rg, err, csireplicator.MaxNumberOfConditions) {
+		return r.Status().Update(ctx, rg.DeepCopy())
+	}
+	return nil
+}
+
 func makeNamespaceReference(namespace string) *v1.Namespace {
 	return &v1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -32,12 +32,15 @@ import (
 	"github.com/stretchr/testify/suite"
 	v1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -429,6 +432,247 @@ func (suite *RGControllerTestSuite) TestReconcileRGWithSyncCompleteWithError() {
 	suite.Error(err) // RG should not be created again
 }
 
+func (suite *RGControllerTestSuite) TestReconcileRepairSyncCompleteWithoutRemoteRGName() {
+	// scenario: RG with sync complete but without the name of its remote RG, which was created with another name
+	remoteRGName := fmt.Sprintf("SourceClusterId-%s-%s", suite.driver.SourceClusterID, suite.driver.RGName)
+	remoteRG := suite.getRemoteRG(remoteRGName, suite.driver.SourceClusterID)
+	rClient, err := suite.config.GetConnection(suite.driver.RemoteClusterID)
+	suite.NoError(err)
+	suite.NoError(rClient.CreateReplicationGroup(context.Background(), remoteRG))
+
+	rg := suite.getRGWithSyncComplete(suite.driver.RGName)
+	delete(rg.Annotations, controllers.RemoteReplicationGroup)
+	rg.Finalizers = []string{controllers.RGFinalizer}
+	suite.createSCAndRG(suite.getTypicalSC(), rg)
+
+	req := suite.getTypicalRequest()
+	_, err = suite.reconciler.Reconcile(context.Background(), req)
+	suite.NoError(err)
+
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err)
+	suite.Equal(remoteRGName, rg.Annotations[controllers.RemoteReplicationGroup])
+	suite.Equal("yes", rg.Annotations[controllers.RGSyncComplete])
+
+	remoteRG, err = rClient.GetReplicationGroup(context.Background(), remoteRGName)
+	suite.NoError(err)
+	suite.Equal(suite.driver.RGName, remoteRG.Annotations[controllers.RemoteReplicationGroup])
+	suite.Equal(suite.driver.SourceClusterID, remoteRG.Annotations[controllers.RemoteClusterID])
+
+	rgList, err := rClient.ListReplicationGroup(context.Background())
+	suite.NoError(err)
+	suite.Len(rgList.Items, 1, "no other remote RG created")
+}
+
+func (suite *RGControllerTestSuite) TestReconcileRepairRequested() {
+	// scenario: repair requested for an RG whose sync was interrupted before the annotations were added
+	remoteRG := suite.getRemoteRG("replica-"+suite.driver.RGName, suite.driver.SourceClusterID)
+	rClient, err := suite.config.GetConnection(suite.driver.RemoteClusterID)
+	suite.NoError(err)
+	suite.NoError(rClient.CreateReplicationGroup(context.Background(), remoteRG))
+
+	rg := suite.getRGWithoutSyncComplete(suite.driver.RGName, true, false)
+	delete(rg.Annotations, controllers.RemoteReplicationGroup)
+	rg.Annotations[controllers.RepairRequested] = "yes"
+	suite.createSCAndRG(suite.getTypicalSC(), rg)
+
+	req := suite.getTypicalRequest()
+	_, err = suite.reconciler.Reconcile(context.Background(), req)
+	suite.NoError(err)
+
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err)
+	suite.Equal("replica-"+suite.driver.RGName, rg.Annotations[controllers.RemoteReplicationGroup])
+	suite.Equal("yes", rg.Annotations[controllers.RGSyncComplete])
+	suite.NotContains(rg.Annotations, controllers.RepairRequested)
+}
+
+func (suite *RGControllerTestSuite) TestReconcileRepairRequestedWithRemoteRGPairedWithAnotherRG() {
+	// scenario: repair requested, but the remote RG points back to another RG paired with it
+	ctx := context.Background()
+	remoteRG := suite.getRemoteRG("replica-"+suite.driver.RGName, suite.driver.SourceClusterID)
+	remoteRG.Annotations = map[string]string{
+		controllers.RemoteReplicationGroup: "rg-other",
+		controllers.RemoteClusterID:        suite.driver.SourceClusterID,
+	}
+	rClient, err := suite.config.GetConnection(suite.driver.RemoteClusterID)
+	suite.NoError(err)
+	suite.NoError(rClient.CreateReplicationGroup(ctx, remoteRG))
+	suite.NoError(suite.client.Create(ctx, suite.getRGWithoutSyncComplete("rg-other", true, false)))
+
+	rg := suite.getRGWithoutSyncComplete(suite.driver.RGName, true, false)
+	delete(rg.Annotations, controllers.RemoteReplicationGroup)
+	rg.Annotations[controllers.RepairRequested] = "yes"
+	suite.createSCAndRG(suite.getTypicalSC(), rg)
+
+	req := suite.getTypicalRequest()
+	_, err = suite.reconciler.Reconcile(ctx, req)
+	suite.NoError(err)
+
+	err = suite.client.Get(ctx, req.NamespacedName, rg)
+	suite.NoError(err)
+	suite.NotContains(rg.Annotations, controllers.RepairRequested)
+	suite.NotContains(rg.Annotations, controllers.RGSyncComplete)
+	suite.Contains(<-suite.reconciler.EventRecorder.(*record.FakeRecorder).Events, "is paired with ReplicationGroup rg-other")
+	remoteRG, err = rClient.GetReplicationGroup(ctx, remoteRG.Name)
+	suite.NoError(err)
+	suite.Equal("rg-other", remoteRG.Annotations[controllers.RemoteReplicationGroup], "remote RG isn't taken over")
+
+	// A forced repair takes the remote RG over
+	rg.Annotations[controllers.RepairRequested] = controllers.RepairForced
+	suite.NoError(suite.client.Update(ctx, rg))
+	_, err = suite.reconciler.Reconcile(ctx, req)
+	suite.NoError(err)
+
+	err = suite.client.Get(ctx, req.NamespacedName, rg)
+	suite.NoError(err)
+	suite.Equal(remoteRG.Name, rg.Annotations[controllers.RemoteReplicationGroup])
+	suite.NotContains(rg.Annotations, controllers.RepairRequested)
+	remoteRG, err = rClient.GetReplicationGroup(ctx, remoteRG.Name)
+	suite.NoError(err)
+	suite.Equal(suite.driver.RGName, remoteRG.Annotations[controllers.RemoteReplicationGroup])
+}
+
+func (suite *RGControllerTestSuite) TestReconcileRepairRequestedWithoutRemoteRG() {
+	// scenario: repair requested but there is no paired RG on the remote cluster
+	rg := suite.getRGWithoutSyncComplete(suite.driver.RGName, true, false)
+	rg.Annotations[controllers.RepairRequested] = "yes"
+	suite.createSCAndRG(suite.getTypicalSC(), rg)
+
+	req := suite.getTypicalRequest()
+	_, err := suite.reconciler.Reconcile(context.Background(), req)
+	suite.NoError(err)
+
+	err = suite.client.Get(context.Background(), req.NamespacedName, rg)
+	suite.NoError(err)
+	suite.NotContains(rg.Annotations, controllers.RepairRequested)
+	suite.NotContains(rg.Annotations, controllers.RGSyncComplete)
+	suite.Contains(<-suite.reconciler.EventRecorder.(*record.FakeRecorder).Events, "No paired ReplicationGroup found")
+}
+
+func (suite *RGControllerTestSuite) TestAnnotationRepairerScan() {
+	synced := suite.getRGWithSyncComplete("rg-synced")
+	halfSynced := suite.getRGWithSyncComplete("rg-half-synced")
+	delete(halfSynced.Annotations, controllers.RemoteReplicationGroup)
+	interrupted := suite.getRGWithoutSyncComplete("rg-interrupted", true, false)
+	interrupted.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	neverSynced := suite.getRGWithoutSyncComplete("rg-never-synced", true, false)
+	neverSynced.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	neverSynced.Spec.ProtectionGroupID = "never-synced-pg"
+	fresh := suite.getRGWithoutSyncComplete("rg-fresh", true, false)
+	fresh.CreationTimestamp = metav1.Now()
+	for _, rg := range []*repv1.DellCSIReplicationGroup{synced, halfSynced, interrupted, neverSynced, fresh} {
+		suite.NoError(suite.client.Create(context.Background(), rg))
+	}
+	// The sync of rg-interrupted has been interrupted after its remote RG was created
+	rClient, err := suite.config.GetConnection(suite.driver.RemoteClusterID)
+	suite.NoError(err)
+	suite.NoError(rClient.CreateReplicationGroup(context.Background(), suite.getRGWithoutSyncComplete("rg-interrupted", false, false)))
+
+	countingConfig := &listCountingConfig{MultiClusterClient: suite.config}
+	repairer := &AnnotationRepairer{
+		Client:   suite.client,
+		Log:      ctrl.Log.WithName("AnnotationRepairer"),
+		Config:   countingConfig,
+		Interval: time.Minute,
+	}
+	suite.NoError(repairer.scan(context.Background()))
+	suite.Equal(1, countingConfig.lists, "Remote RGs are listed once per scan")
+
+	expected := map[string]bool{
+		"rg-synced": false, "rg-half-synced": true, "rg-interrupted": true, "rg-never-synced": false, "rg-fresh": false,
+	}
+	for name, requested := range expected {
+		rg := new(repv1.DellCSIReplicationGroup)
+		suite.NoError(suite.client.Get(context.Background(), types.NamespacedName{Name: name}, rg))
+		_, ok := rg.Annotations[controllers.RepairRequested]
+		suite.Equal(requested, ok, name)
+	}
+}
+
+// listCountingConfig counts the LIST requests sent to the remote clusters
+type listCountingConfig struct {
+	connection.MultiClusterClient
+	lists int
+}
+
+func (c *listCountingConfig) GetConnection(clusterID string) (connection.RemoteClusterClient, error) {
+	rClient, err := c.MultiClusterClient.GetConnection(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	remoteClient := rClient.(*connection.RemoteK8sControllerClient)
+	return &connection.RemoteK8sControllerClient{
+		ClusterID: remoteClient.ClusterID,
+		Client: interceptor.NewClient(remoteClient.Client.(client.WithWatch), interceptor.Funcs{
+			List: func(ctx context.Context, cl client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				c.lists++
+				return cl.List(ctx, list, opts...)
+			},
+		}),
+	}, nil
+}
+
+func (suite *RGControllerTestSuite) TestPoolCapacityPublisher() {
+	ctx := context.Background()
+	sc := utils.GetReplicationEnabledSC(suite.driver.DriverName, "sc-most-free", suite.driver.RemoteSCName, suite.driver.RemoteClusterID)
+	sc.Parameters[controllers.RemotePoolPlacement] = controllers.PlacementMostFree
+	sc.Parameters[controllers.RemotePools] = "pool-a,pool-b,pool-c"
+	sc.Parameters[controllers.RemotePoolParameter] = "storagepool"
+	roundRobin := utils.GetReplicationEnabledSC(suite.driver.DriverName, "sc-round-robin", suite.driver.RemoteSCName, suite.driver.RemoteClusterID)
+	roundRobin.Parameters[controllers.RemotePoolPlacement] = controllers.PlacementRoundRobin
+	roundRobin.Parameters[controllers.RemotePools] = "pool-a,pool-b"
+	roundRobin.Parameters[controllers.RemotePoolParameter] = "storagepool"
+	suite.NoError(suite.client.Create(ctx, sc))
+	suite.NoError(suite.client.Create(ctx, roundRobin))
+
+	// The target cluster has a storage class per pool, the capacity of pool-c isn't published
+	rClient, err := suite.config.GetConnection(suite.driver.RemoteClusterID)
+	suite.NoError(err)
+	remoteClient := rClient.(*connection.RemoteK8sControllerClient).Client
+	for name, pool := range map[string]string{"remote-a": "pool-a", "remote-b": "pool-b", "remote-c": "pool-c"} {
+		remoteSC := utils.GetNonReplicationEnabledSC(suite.driver.DriverName, name)
+		remoteSC.Parameters["storagepool"] = pool
+		suite.NoError(remoteClient.Create(ctx, remoteSC))
+	}
+	otherDriver := utils.GetNonReplicationEnabledSC("other.driver", "remote-other")
+	otherDriver.Parameters["storagepool"] = "pool-b"
+	suite.NoError(remoteClient.Create(ctx, otherDriver))
+	for name, c := range map[string]struct {
+		sc       string
+		capacity string
+	}{
+		"capacity-a-1": {"remote-a", "10Gi"}, "capacity-a-2": {"remote-a", "20Gi"},
+		"capacity-b": {"remote-b", "15Gi"}, "capacity-other": {"remote-other", "100Gi"},
+	} {
+		capacity := resource.MustParse(c.capacity)
+		suite.NoError(remoteClient.Create(ctx, &storagev1.CSIStorageCapacity{
+			ObjectMeta:       metav1.ObjectMeta{Name: name, Namespace: "driver-ns"},
+			StorageClassName: c.sc,
+			Capacity:         &capacity,
+		}))
+	}
+
+	publisher := &PoolCapacityPublisher{
+		Client:   suite.client,
+		Log:      ctrl.Log.WithName("PoolCapacityPublisher"),
+		Config:   suite.config,
+		Interval: time.Minute,
+	}
+	suite.NoError(publisher.publish(ctx))
+
+	updatedSC := new(storagev1.StorageClass)
+	suite.NoError(suite.client.Get(ctx, types.NamespacedName{Name: sc.Name}, updatedSC))
+	capacity, err := controllers.ParsePoolCapacity(updatedSC.Annotations[controllers.RemotePoolCapacity])
+	suite.NoError(err)
+	suite.Equal(map[string]int64{"pool-a": 20 << 30, "pool-b": 15 << 30}, capacity.Pools)
+	suite.False(capacity.IsStale(time.Now()))
+	suite.True(capacity.IsStale(time.Now().Add(time.Hour)))
+
+	suite.NoError(suite.client.Get(ctx, types.NamespacedName{Name: roundRobin.Name}, updatedSC))
+	suite.NotContains(updatedSC.Annotations, controllers.RemotePoolCapacity)
+}
+
 func (suite *RGControllerTestSuite) TestRGSyncDeletion() {
 	// scenario: Test Remote RG sync deletion
 	newConfig := config.NewFakeConfigForSingleCluster(suite.client,
@@ -471,6 +715,30 @@ func (suite *RGControllerTestSuite) TestRGSyncDeletion() {
 	suite.Equal(false, resp.Requeue)
 }
 
+func (suite *RGControllerTestSuite) TestRGDeletionWithMalformedRetentionPolicyInStrictMode() {
+	ctx := context.Background()
+	rClient, err := suite.config.GetConnection(suite.driver.RemoteClusterID)
+	suite.NoError(err)
+	err = rClient.CreateReplicationGroup(ctx, suite.getRemoteRG(suite.driver.RGName, suite.driver.SourceClusterID))
+	suite.NoError(err)
+
+	rg := suite.getRGWithoutSyncComplete(suite.driver.RGName, true, false)
+	rg.Annotations[controllers.RemoteRGRetentionPolicy] = "purge"
+	suite.createSCAndRG(suite.getTypicalSC(), rg)
+	err = suite.client.Delete(ctx, rg)
+	suite.NoError(err)
+
+	suite.reconciler.Strict = true
+	_, err = suite.reconciler.Reconcile(ctx, suite.getTypicalRequest())
+	suite.NoError(err)
+
+	err = suite.client.Get(ctx, suite.getTypicalRequest().NamespacedName, rg)
+	suite.True(errors.IsNotFound(err), "Malformed retention policy doesn't block the deletion")
+	remoteRG, err := rClient.GetReplicationGroup(ctx, suite.driver.RGName)
+	suite.NoError(err)
+	suite.NotContains(remoteRG.Annotations, controllers.DeletionRequested, "Remote RG is retained")
+}
+
 func (suite *RGControllerTestSuite) TestSetupWithManagerRg() {
 	suite.Init()
 	mgr := manager.Manager(nil)
@@ -51,12 +51,13 @@ const (
 // PersistentVolumeReconciler reconciles a PersistentVolume object
 type PersistentVolumeReconciler struct {
 	client.Client
-	Log                logr.Logger
-	Scheme             *runtime.Scheme
-	EventRecorder      record.EventRecorder
-	PVCRequeueInterval time.Duration
-	Config             connection.MultiClusterClient
-	Domain             string
+	Log           logr.Logger
+	Scheme        *runtime.Scheme
+	EventRecorder record.EventRecorder
+	Config        connection.MultiClusterClient
+	Domain        string
+	// Strict reports malformed annotations with an event instead of falling back to the defaults
+	Strict bool
 }
 
 // +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;update;patch;list;watch;delete
@@ -65,6 +66,7 @@ type PersistentVolumeReconciler struct {
 func (r *PersistentVolumeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("persistentvolume", req.Name)
 	ctx = context.WithValue(ctx, common.LoggerContextKey, log)
+	ctx = connection.WithFeature(ctx, connection.FeaturePVSync)
 
 	r.Log.V(common.InfoLevel).Info("Reconciling PV event")
 
@@ -111,6 +113,17 @@ func (r *PersistentVolumeReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if !ok {
 		log.V(common.InfoLevel).Info("Retention policy not set, using retain as the default policy")
 		retentionPolicy = "retain" // we will default to retain the PV if there is no retention policy is set
+	} else if err := controller.ValidateRetentionPolicy(retentionPolicy); err != nil && r.Strict {
+		log.Error(err, "Malformed retention policy annotation in strict mode")
+		if volume.DeletionTimestamp.IsZero() {
+			r.EventRecorder.Eventf(volume, eventTypeWarning, controller.DegradedCondition,
+				"malformed %s annotation: %s", controller.RemotePVRetentionPolicy, err.Error())
+			return ctrl.Result{}, nil
+		}
+		// The malformed policy must not block the deletion, the remote PV is retained
+		r.EventRecorder.Eventf(volume, eventTypeWarning, controller.DegradedCondition,
+			"malformed %s annotation: %s, retaining the remote PV", controller.RemotePVRetentionPolicy, err.Error())
+		retentionPolicy = controller.RemoteRetentionValueRetain
 	}
 
 	// Handle PV deletion if timestamp is set
@@ -138,7 +151,7 @@ func (r *PersistentVolumeReconciler) Reconcile(ctx context.Context, req ctrl.Req
 						// also apply new annotation - SynchronizedDeletionStatus
 						log.V(common.InfoLevel).Info("Adding sync delete annotation to remote")
 						controller.AddAnnotation(remoteVolumeCopy, controller.SynchronizedDeletionStatus, "requested")
-						err := rClient.UpdatePersistentVolume(ctx, remoteVolumeCopy)
+						err := rClient.UpdatePersistentVolume(connection.WithFeature(ctx, connection.FeatureDeletion), remoteVolumeCopy)
 						if err != nil {
 							log.V(common.InfoLevel).Info("Error encountered in updating remote volume")
 							return ctrl.Result{}, err
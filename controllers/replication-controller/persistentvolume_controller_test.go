@@ -30,6 +30,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -448,6 +449,38 @@ func (suite *PVReconcileSuite) TestRemoteSCDoesNotExist() {
 	suite.Error(err, "remote storage class doesn't exist")
 }
 
+func (suite *PVReconcileSuite) TestDeletionWithMalformedRetentionPolicyInStrictMode() {
+	ctx := context.Background()
+	remoteClient, err := suite.fakeConfig.GetConnection("remote-123")
+	suite.NoError(err)
+	remotePV := utils.GetPVObj("fake-remote-pv05", "fakeHandle", suite.driver.DriverName, suite.driver.StorageClass, nil)
+	err = remoteClient.CreatePersistentVolume(ctx, remotePV)
+	suite.NoError(err)
+
+	pvObj := utils.GetPVObj("fake-pv05", "fakeHandle", suite.driver.DriverName, suite.driver.StorageClass, nil)
+	pvObj.Annotations = map[string]string{
+		controllers.RemoteClusterID:         "remote-123",
+		controllers.RemotePV:                remotePV.Name,
+		controllers.RemotePVRetentionPolicy: "purge",
+	}
+	pvObj.Finalizers = []string{controllers.ReplicationFinalizer}
+	err = suite.client.Create(ctx, pvObj)
+	suite.NoError(err)
+	err = suite.client.Delete(ctx, pvObj)
+	suite.NoError(err)
+
+	suite.reconciler.Strict = true
+	defer func() { suite.reconciler.Strict = false }()
+	_, err = suite.reconciler.Reconcile(ctx, suite.getTypicalRequest("fake-pv05"))
+	suite.NoError(err)
+
+	err = suite.client.Get(ctx, types.NamespacedName{Name: "fake-pv05"}, pvObj)
+	suite.True(errors.IsNotFound(err), "Malformed retention policy doesn't block the deletion")
+	remotePV, err = remoteClient.GetPersistentVolume(ctx, remotePV.Name)
+	suite.NoError(err)
+	suite.NotContains(remotePV.Annotations, controllers.DeletionRequested, "Remote PV is retained")
+}
+
 func (suite *PVReconcileSuite) initReconciler(config connection.MultiClusterClient) {
 	fakeRecorder := record.NewFakeRecorder(100)
 	reconciler := PersistentVolumeReconciler{
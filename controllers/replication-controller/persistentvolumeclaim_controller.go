@@ -16,7 +16,6 @@ package replicationcontroller
 
 import (
 	"context"
-	"time"
 
 	controller "github.com/dell/csm-replication/controllers"
 	"github.com/dell/csm-replication/pkg/common"
@@ -39,12 +38,13 @@ import (
 // PersistentVolumeClaimReconciler reconciles a PersistentVolumeClaim object
 type PersistentVolumeClaimReconciler struct {
 	client.Client
-	Log                logr.Logger
-	Scheme             *runtime.Scheme
-	EventRecorder      record.EventRecorder
-	PVCRequeueInterval time.Duration
-	Config             connection.MultiClusterClient
-	Domain             string
+	Log           logr.Logger
+	Scheme        *runtime.Scheme
+	EventRecorder record.EventRecorder
+	// PVCRequeue schedules the periodic reconciles of the PVCs, disabled if nil
+	PVCRequeue *controller.AdaptiveRequeue
+	Config     connection.MultiClusterClient
+	Domain     string
 }
 
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
@@ -58,10 +58,12 @@ func (r *PersistentVolumeClaimReconciler) Reconcile(ctx context.Context, req ctr
 	// If we have received the reconcile request, it means that the sidecar has completed its protection
 	log := r.Log.WithValues("persistentvolumeclaim", req.NamespacedName)
 	ctx = context.WithValue(ctx, common.LoggerContextKey, log)
+	ctx = connection.WithFeature(ctx, connection.FeaturePVCSync)
 
 	claim := new(v1.PersistentVolumeClaim)
 	err := r.Get(ctx, req.NamespacedName, claim)
 	if err != nil && errors.IsNotFound(err) {
+		r.PVCRequeue.Forget(req.NamespacedName)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	} else if err != nil {
 		return ctrl.Result{}, err
@@ -158,7 +160,7 @@ func (r *PersistentVolumeClaimReconciler) Reconcile(ctx context.Context, req ctr
 	}
 
 	log.V(common.InfoLevel).Info("PVC Reconcile complete!!!!")
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: r.PVCRequeue.Next(claim)}, nil
 }
 
 func (r *PersistentVolumeClaimReconciler) processRemotePVC(ctx context.Context,
@@ -0,0 +1,170 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package replicationcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	controller "github.com/dell/csm-replication/controllers"
+	"github.com/dell/csm-replication/pkg/common"
+	"github.com/dell/csm-replication/pkg/connection"
+	"github.com/go-logr/logr"
+	storageV1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// poolCapacityValidity is the number of intervals after which the published capacity is considered stale
+const poolCapacityValidity = 3
+
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=csistoragecapacities,verbs=get;list;watch
+
+// PoolCapacityPublisher periodically publishes the available capacity of the pools of the target arrays
+// on the storage classes using the most-free pool placement, where the csi-replicator sidecar reads it.
+// The capacity of a pool is the one reported by the CSIStorageCapacity objects of the target cluster
+// for the storage classes of the driver selecting that pool
+type PoolCapacityPublisher struct {
+	client.Client
+	Log    logr.Logger
+	Config connection.MultiClusterClient
+	// Interval between the publications of the capacity
+	Interval time.Duration
+}
+
+// Start publishes the capacity every interval until the context is cancelled. Implements manager.Runnable
+func (p *PoolCapacityPublisher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := p.publish(ctx); err != nil {
+				p.Log.Error(err, "Failed to publish the capacity of the remote pools")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection makes sure that only the leader publishes the capacity. Implements manager.LeaderElectionRunnable
+func (p *PoolCapacityPublisher) NeedLeaderElection() bool {
+	return true
+}
+
+// remoteCapacity is the storage classes and the CSIStorageCapacity objects of a target cluster
+type remoteCapacity struct {
+	storageClasses []storageV1.StorageClass
+	capacities     []storageV1.CSIStorageCapacity
+}
+
+// publish updates the RemotePoolCapacity annotation of the storage classes using the most-free placement.
+// The objects of each target cluster are listed once per publication
+func (p *PoolCapacityPublisher) publish(ctx context.Context) error {
+	scList := new(storageV1.StorageClassList)
+	if err := p.List(ctx, scList); err != nil {
+		return err
+	}
+	remotes := make(map[string]*remoteCapacity)
+	now := metav1.Now()
+	for i := range scList.Items {
+		sc := &scList.Items[i]
+		if strings.ToLower(strings.TrimSpace(sc.Parameters[controller.RemotePoolPlacement])) != controller.PlacementMostFree {
+			continue
+		}
+		parameter := strings.TrimSpace(sc.Parameters[controller.RemotePoolParameter])
+		pools := controller.ParsePools(sc.Parameters[controller.RemotePools])
+		if parameter == "" || len(pools) == 0 {
+			continue
+		}
+		remoteClusterID := sc.Parameters[controller.RemoteClusterID]
+		remote, ok := remotes[remoteClusterID]
+		if !ok {
+			var err error
+			if remote, err = p.getRemoteCapacity(ctx, remoteClusterID); err != nil {
+				p.Log.Error(err, "Failed to get the capacity of the remote cluster", "ClusterId", remoteClusterID)
+			}
+			// A failed cluster isn't retried until the next publication
+			remotes[remoteClusterID] = remote
+		}
+		if remote == nil {
+			continue
+		}
+
+		capacity := controller.PoolCapacity{
+			Timestamp:  now,
+			ValidUntil: metav1.NewTime(now.Add(poolCapacityValidity * p.Interval)),
+			Pools:      remote.poolCapacity(sc.Provisioner, parameter, pools),
+		}
+		buffer, err := json.Marshal(capacity)
+		if err != nil {
+			return err
+		}
+		p.Log.V(common.DebugLevel).Info("Publishing the capacity of the remote pools", "StorageClassName", sc.Name, "pools", capacity.Pools)
+		controller.AddAnnotation(sc, controller.RemotePoolCapacity, string(buffer))
+		if err := p.Update(ctx, sc); err != nil {
+			p.Log.Error(err, "Failed to publish the capacity of the remote pools", "StorageClassName", sc.Name)
+		}
+	}
+	return nil
+}
+
+// getRemoteCapacity lists the storage classes and the CSIStorageCapacity objects of the target cluster
+func (p *PoolCapacityPublisher) getRemoteCapacity(ctx context.Context, clusterID string) (*remoteCapacity, error) {
+	remoteClient, err := p.Config.GetConnection(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	scList, err := remoteClient.ListStorageClass(ctx)
+	if err != nil {
+		return nil, err
+	}
+	capacityList, err := remoteClient.ListStorageCapacities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteCapacity{storageClasses: scList.Items, capacities: capacityList.Items}, nil
+}
+
+// poolCapacity returns the largest capacity reported for each of the pools, pools with unknown capacity are omitted
+func (r *remoteCapacity) poolCapacity(provisioner, parameter string, pools []string) map[string]int64 {
+	scPools := make(map[string]string)
+	for _, sc := range r.storageClasses {
+		if sc.Provisioner != provisioner {
+			continue
+		}
+		for _, pool := range pools {
+			if sc.Parameters[parameter] == pool {
+				scPools[sc.Name] = pool
+			}
+		}
+	}
+
+	capacity := make(map[string]int64)
+	for _, c := range r.capacities {
+		pool, ok := scPools[c.StorageClassName]
+		if !ok || c.Capacity == nil {
+			continue
+		}
+		if available, ok := capacity[pool]; !ok || c.Capacity.Value() > available {
+			capacity[pool] = c.Capacity.Value()
+		}
+	}
+	return capacity
+}
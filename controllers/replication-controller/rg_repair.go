@@ -0,0 +1,295 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package replicationcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	repv1 "github.com/dell/csm-replication/api/v1"
+	controller "github.com/dell/csm-replication/controllers"
+	"github.com/dell/csm-replication/pkg/common"
+	"github.com/dell/csm-replication/pkg/connection"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const eventReasonRepaired = "Repaired"
+
+// needsRepair returns true if the pairing annotations of the RG have to be rebuilt from its remote RG.
+// That's the case if a repair has been requested or if the RG is marked as synced without the name of its remote RG
+func needsRepair(rg *repv1.DellCSIReplicationGroup) bool {
+	if _, ok := rg.Annotations[controller.RepairRequested]; ok {
+		return true
+	}
+	return rg.Annotations[controller.RGSyncComplete] == "yes" && rg.Annotations[controller.RemoteReplicationGroup] == ""
+}
+
+// findRemoteRG returns the RG paired with the local RG on the remote cluster, nil if there is none.
+// The RG named by the RemoteReplicationGroup annotation is preferred, otherwise the remote RGs are matched by their protection groups
+func findRemoteRG(ctx context.Context, localRG *repv1.DellCSIReplicationGroup, remoteClient connection.RemoteClusterClient, localClusterID string) (*repv1.DellCSIReplicationGroup, error) {
+	if name := localRG.Annotations[controller.RemoteReplicationGroup]; name != "" {
+		remoteRG, err := remoteClient.GetReplicationGroup(ctx, name)
+		if err == nil && isPaired(localRG, remoteRG, localClusterID) {
+			return remoteRG, nil
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	rgList, err := remoteClient.ListReplicationGroup(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newRemoteRGIndex(rgList).find(localRG, localClusterID)
+}
+
+// remoteRGIndex indexes the RGs of a remote cluster by name and protection group,
+// so that they are listed once for all the local RGs paired with that cluster
+type remoteRGIndex struct {
+	byName            map[string]*repv1.DellCSIReplicationGroup
+	byProtectionGroup map[string][]*repv1.DellCSIReplicationGroup
+}
+
+func newRemoteRGIndex(rgList *repv1.DellCSIReplicationGroupList) *remoteRGIndex {
+	index := &remoteRGIndex{
+		byName:            make(map[string]*repv1.DellCSIReplicationGroup, len(rgList.Items)),
+		byProtectionGroup: make(map[string][]*repv1.DellCSIReplicationGroup),
+	}
+	for i := range rgList.Items {
+		rg := &rgList.Items[i]
+		index.byName[rg.Name] = rg
+		index.byProtectionGroup[rg.Spec.ProtectionGroupID] = append(index.byProtectionGroup[rg.Spec.ProtectionGroupID], rg)
+	}
+	return index
+}
+
+// find returns the RG paired with the local RG, nil if there is none.
+// The RG named by the RemoteReplicationGroup annotation is preferred, otherwise the RGs are matched by their protection groups
+func (index *remoteRGIndex) find(localRG *repv1.DellCSIReplicationGroup, localClusterID string) (*repv1.DellCSIReplicationGroup, error) {
+	if rg, ok := index.byName[localRG.Annotations[controller.RemoteReplicationGroup]]; ok && isPaired(localRG, rg, localClusterID) {
+		return rg, nil
+	}
+	var found *repv1.DellCSIReplicationGroup
+	for _, rg := range index.byProtectionGroup[localRG.Spec.RemoteProtectionGroupID] {
+		if !isPaired(localRG, rg, localClusterID) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("found multiple remote RGs paired with %s: %s, %s", localRG.Name, found.Name, rg.Name)
+		}
+		found = rg
+	}
+	return found, nil
+}
+
+// isPaired returns true if the remote RG mirrors the protection groups of the local RG
+func isPaired(localRG, remoteRG *repv1.DellCSIReplicationGroup, localClusterID string) bool {
+	// In the single cluster scenario the local RG is listed as well
+	if remoteRG.Name == localRG.Name && localClusterID == controller.Self {
+		return false
+	}
+	return remoteRG.Spec.DriverName == localRG.Spec.DriverName &&
+		remoteRG.Spec.RemoteClusterID == localClusterID &&
+		remoteRG.Spec.ProtectionGroupID == localRG.Spec.RemoteProtectionGroupID &&
+		remoteRG.Spec.RemoteProtectionGroupID == localRG.Spec.ProtectionGroupID
+}
+
+// repairAnnotations rebuilds the pairing annotations of the local RG, as well as the ones of its remote RG, from the remote RG.
+// It returns true if the local RG has been updated, in which case the reconcile has to wait for the next event
+func (r *ReplicationGroupReconciler) repairAnnotations(ctx context.Context, localRG *repv1.DellCSIReplicationGroup, remoteClient connection.RemoteClusterClient, localClusterID string) (bool, error) {
+	log := common.GetLoggerFromContext(ctx)
+	ctx = connection.WithFeature(ctx, connection.FeatureRepair)
+	remoteClusterID := localRG.Spec.RemoteClusterID
+
+	remoteRG, err := findRemoteRG(ctx, localRG, remoteClient, localClusterID)
+	if err != nil {
+		log.Error(err, "Failed to find the remote RG to repair the annotations from")
+		r.EventRecorder.Eventf(localRG, eventTypeWarning, eventReasonRepaired,
+			"Failed to repair the annotations: %s", err.Error())
+		return false, err
+	}
+	if remoteRG == nil {
+		log.V(common.InfoLevel).Info("No paired RG found on the remote cluster, nothing to repair")
+		if _, ok := localRG.Annotations[controller.RepairRequested]; ok {
+			r.EventRecorder.Eventf(localRG, eventTypeWarning, eventReasonRepaired,
+				"No paired ReplicationGroup found on ClusterId: %s", remoteClusterID)
+			delete(localRG.Annotations, controller.RepairRequested)
+			return true, r.Update(ctx, localRG)
+		}
+		return false, nil
+	}
+
+	// The remote RG points back to the local RG
+	if remoteRG.Annotations[controller.RemoteReplicationGroup] != localRG.Name ||
+		remoteRG.Annotations[controller.RemoteClusterID] != localClusterID {
+		pairedRG, err := r.getPairedLocalRG(ctx, remoteRG, localClusterID)
+		if err != nil {
+			return false, err
+		}
+		if pairedRG != "" && localRG.Annotations[controller.RepairRequested] != controller.RepairForced {
+			log.V(common.InfoLevel).Info("Remote RG is paired with another RG, not repairing", "remoteRG", remoteRG.Name, "pairedRG", pairedRG)
+			if _, ok := localRG.Annotations[controller.RepairRequested]; ok {
+				r.EventRecorder.Eventf(localRG, eventTypeWarning, eventReasonRepaired,
+					"Remote ReplicationGroup %s on ClusterId: %s is paired with ReplicationGroup %s, use repctl repair --force to repair anyway",
+					remoteRG.Name, remoteClusterID, pairedRG)
+				delete(localRG.Annotations, controller.RepairRequested)
+				return true, r.Update(ctx, localRG)
+			}
+			return false, nil
+		}
+		log.V(common.InfoLevel).Info("Repairing the annotations of the remote RG", "remoteRG", remoteRG.Name)
+		controller.AddAnnotation(remoteRG, controller.RemoteReplicationGroup, localRG.Name)
+		controller.AddAnnotation(remoteRG, controller.RemoteClusterID, localClusterID)
+		if err := remoteClient.UpdateReplicationGroup(ctx, remoteRG); err != nil {
+			return false, err
+		}
+	}
+
+	log.V(common.InfoLevel).Info("Repairing the annotations of the local RG", "remoteRG", remoteRG.Name)
+	controller.AddAnnotation(localRG, controller.RemoteReplicationGroup, remoteRG.Name)
+	controller.AddAnnotation(localRG, controller.RGSyncComplete, "yes")
+	delete(localRG.Annotations, controller.RepairRequested)
+	if err := r.Update(ctx, localRG); err != nil {
+		return false, err
+	}
+	r.EventRecorder.Eventf(localRG, eventTypeNormal, eventReasonRepaired,
+		"Repaired the annotations from remote ReplicationGroup %s on ClusterId: %s", remoteRG.Name, remoteClusterID)
+	return true, nil
+}
+
+// getPairedLocalRG returns the name of the local RG the remote RG points back to, if it's another existing RG paired with it.
+// Empty if the remote RG doesn't point back to any local RG, or if the RG it points back to is gone or no longer paired with it
+func (r *ReplicationGroupReconciler) getPairedLocalRG(ctx context.Context, remoteRG *repv1.DellCSIReplicationGroup, localClusterID string) (string, error) {
+	name := remoteRG.Annotations[controller.RemoteReplicationGroup]
+	if name == "" || remoteRG.Annotations[controller.RemoteClusterID] != localClusterID {
+		return "", nil
+	}
+	pairedRG := new(repv1.DellCSIReplicationGroup)
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, pairedRG); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if !pairedRG.DeletionTimestamp.IsZero() || !isPaired(pairedRG, remoteRG, localClusterID) {
+		return "", nil
+	}
+	return name, nil
+}
+
+// AnnotationRepairer periodically requests the repair of the RGs stuck in a half-synced state
+type AnnotationRepairer struct {
+	client.Client
+	Log    logr.Logger
+	Config connection.MultiClusterClient
+	// Interval between the scans of the RGs
+	Interval time.Duration
+}
+
+// Start scans the RGs every interval until the context is cancelled. Implements manager.Runnable
+func (a *AnnotationRepairer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(a.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := a.scan(ctx); err != nil {
+				a.Log.Error(err, "Failed to scan the RGs for half-synced annotations")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection makes sure that only the leader scans the RGs. Implements manager.LeaderElectionRunnable
+func (a *AnnotationRepairer) NeedLeaderElection() bool {
+	return true
+}
+
+// scan requests the repair of the half-synced RGs. The repair itself is done by the RG reconciler.
+// RGs never marked as synced are half-synced if they are older than the interval and their remote RG,
+// which points back to them, has been created. The RGs of each remote cluster are listed once per scan
+func (a *AnnotationRepairer) scan(ctx context.Context) error {
+	rgList := new(repv1.DellCSIReplicationGroupList)
+	if err := a.List(ctx, rgList); err != nil {
+		return err
+	}
+	indexes := make(map[string]*remoteRGIndex)
+	for i := range rgList.Items {
+		rg := &rgList.Items[i]
+		if !rg.DeletionTimestamp.IsZero() || rg.Annotations == nil {
+			continue
+		}
+		if _, ok := rg.Annotations[controller.RepairRequested]; ok {
+			continue
+		}
+		if !needsRepair(rg) && !a.isSyncInterrupted(ctx, rg, indexes) {
+			continue
+		}
+		a.Log.V(common.InfoLevel).Info("Requesting repair of half-synced RG", "rg", rg.Name)
+		controller.AddAnnotation(rg, controller.RepairRequested, "yes")
+		if err := a.Update(ctx, rg); err != nil {
+			a.Log.Error(err, "Failed to request repair of RG", "rg", rg.Name)
+		}
+	}
+	return nil
+}
+
+// isSyncInterrupted returns true if the RG hasn't been marked as synced in time, although its remote RG has been created.
+// The indexes of the remote RGs are shared by the RGs of the scan, keyed by remote cluster
+func (a *AnnotationRepairer) isSyncInterrupted(ctx context.Context, rg *repv1.DellCSIReplicationGroup, indexes map[string]*remoteRGIndex) bool {
+	if rg.Annotations[controller.RGSyncComplete] == "yes" || time.Since(rg.CreationTimestamp.Time) <= a.Interval {
+		return false
+	}
+	index, ok := indexes[rg.Spec.RemoteClusterID]
+	if !ok {
+		index = a.listRemoteRGs(ctx, rg.Spec.RemoteClusterID)
+		// A failed cluster isn't retried until the next scan
+		indexes[rg.Spec.RemoteClusterID] = index
+	}
+	if index == nil {
+		return false
+	}
+	localClusterID := a.Config.GetClusterID()
+	if rg.Spec.RemoteClusterID == controller.Self {
+		localClusterID = controller.Self
+	}
+	remoteRG, err := index.find(rg, localClusterID)
+	if err != nil {
+		a.Log.Error(err, "Failed to find the remote RG", "rg", rg.Name)
+		return false
+	}
+	return remoteRG != nil && remoteRG.Annotations[controller.RemoteReplicationGroup] == rg.Name
+}
+
+// listRemoteRGs returns the index of the RGs of the remote cluster, nil if they can't be listed
+func (a *AnnotationRepairer) listRemoteRGs(ctx context.Context, clusterID string) *remoteRGIndex {
+	remoteClient, err := a.Config.GetConnection(clusterID)
+	if err != nil {
+		a.Log.Error(err, "Failed to get the connection to the remote cluster", "ClusterId", clusterID)
+		return nil
+	}
+	rgList, err := remoteClient.ListReplicationGroup(connection.WithFeature(ctx, connection.FeatureRepair))
+	if err != nil {
+		a.Log.Error(err, "Failed to list the RGs of the remote cluster", "ClusterId", clusterID)
+		return nil
+	}
+	return newRemoteRGIndex(rgList)
+}
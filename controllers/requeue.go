@@ -0,0 +1,92 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// AdaptiveRequeue schedules the periodic requeues of objects based on how recently they changed.
+// An object which changed since its previous reconcile is requeued after MinInterval, the interval
+// then doubles with every reconcile in which the object is unchanged, up to MaxInterval.
+// Each interval is jittered, so that the requeues of the objects don't happen in bursts
+type AdaptiveRequeue struct {
+	MinInterval  time.Duration
+	MaxInterval  time.Duration
+	JitterFactor float64
+
+	lock    sync.Mutex
+	entries map[types.NamespacedName]requeueEntry
+}
+
+type requeueEntry struct {
+	resourceVersion string
+	interval        time.Duration
+}
+
+// NewAdaptiveRequeue returns new AdaptiveRequeue
+func NewAdaptiveRequeue(minInterval, maxInterval time.Duration, jitterFactor float64) *AdaptiveRequeue {
+	if maxInterval < minInterval {
+		maxInterval = minInterval
+	}
+	return &AdaptiveRequeue{
+		MinInterval:  minInterval,
+		MaxInterval:  maxInterval,
+		JitterFactor: jitterFactor,
+		entries:      make(map[types.NamespacedName]requeueEntry),
+	}
+}
+
+// Next returns the interval after which the object should be reconciled again.
+// Returns 0, which disables the periodic requeue, if the AdaptiveRequeue is nil
+func (a *AdaptiveRequeue) Next(obj metav1.Object) time.Duration {
+	if a == nil || a.MinInterval <= 0 {
+		return 0
+	}
+	key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	entry, ok := a.entries[key]
+	if !ok || entry.resourceVersion != obj.GetResourceVersion() {
+		entry.interval = a.MinInterval
+	} else {
+		entry.interval *= 2
+		if entry.interval > a.MaxInterval {
+			entry.interval = a.MaxInterval
+		}
+	}
+	entry.resourceVersion = obj.GetResourceVersion()
+	a.entries[key] = entry
+	if a.JitterFactor <= 0 {
+		return entry.interval
+	}
+	return wait.Jitter(entry.interval, a.JitterFactor)
+}
+
+// Forget stops tracking the object, it should be called once the object is deleted
+func (a *AdaptiveRequeue) Forget(key types.NamespacedName) {
+	if a == nil {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	delete(a.entries, key)
+}
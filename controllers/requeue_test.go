@@ -0,0 +1,59 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestAdaptiveRequeue(t *testing.T) {
+	requeue := NewAdaptiveRequeue(10*time.Second, 30*time.Second, 0)
+	pvc := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-pvc", Namespace: "fake-ns", ResourceVersion: "1"},
+	}
+
+	// scenario: new PVC is requeued fast, then backs off while it is unchanged
+	assert.Equal(t, 10*time.Second, requeue.Next(pvc))
+	assert.Equal(t, 20*time.Second, requeue.Next(pvc))
+	assert.Equal(t, 30*time.Second, requeue.Next(pvc))
+	assert.Equal(t, 30*time.Second, requeue.Next(pvc))
+
+	// scenario: changed PVC is requeued fast again
+	pvc.ResourceVersion = "2"
+	assert.Equal(t, 10*time.Second, requeue.Next(pvc))
+
+	// scenario: deleted PVC is forgotten
+	assert.Equal(t, 20*time.Second, requeue.Next(pvc))
+	requeue.Forget(types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name})
+	assert.Equal(t, 10*time.Second, requeue.Next(pvc))
+
+	// scenario: jitter never shortens the interval
+	jittered := NewAdaptiveRequeue(10*time.Second, 30*time.Second, DefaultRequeueJitterFactor)
+	interval := jittered.Next(pvc)
+	assert.GreaterOrEqual(t, interval, 10*time.Second)
+	assert.LessOrEqual(t, interval, 12*time.Second)
+
+	// scenario: periodic requeue is disabled
+	var disabled *AdaptiveRequeue
+	assert.Equal(t, time.Duration(0), disabled.Next(pvc))
+	byDefault := NewAdaptiveRequeue(DefaultPVCRequeueMinInterval, DefaultPVCRequeueMaxInterval, DefaultRequeueJitterFactor)
+	assert.Equal(t, time.Duration(0), byDefault.Next(pvc))
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/dell/csm-replication/controllers"
 	"github.com/dell/csm-replication/pkg/common"
 	"github.com/dell/csm-replication/pkg/connection"
+	"github.com/dell/csm-replication/pkg/fips"
 	"github.com/go-logr/logr"
 	"github.com/spf13/viper"
 	v1 "k8s.io/api/core/v1"
@@ -46,6 +47,8 @@ type ControllerManagerOpts struct {
 	ConfigFileName    string
 	InCluster         bool
 	Mode              string
+	// FIPS refuses the remote cluster configs which are not using verified TLS
+	FIPS bool
 }
 
 var isInInvalidState bool
@@ -287,6 +290,11 @@ func getConnHandler(ctx context.Context, targets []target, client ctrlClient.Cli
 				return nil, err
 			}
 		}
+		if opts.FIPS {
+			if err := fips.VerifyRESTConfig(target.ClusterID, restConfig); err != nil {
+				return nil, err
+			}
+		}
 		k8sConnHandler.AddOrUpdateConfig(target.ClusterID, restConfig, log)
 	}
 	// Let's add a connection handler by default for self (single cluster scenario)
@@ -311,6 +319,11 @@ func getConnHandler(ctx context.Context, targets []target, client ctrlClient.Cli
 			return nil, err
 		}
 	}
+	if opts.FIPS {
+		if err := fips.VerifyRESTConfig(controllers.Self, restConfig); err != nil {
+			return nil, err
+		}
+	}
 	k8sConnHandler.AddOrUpdateConfig(controllers.Self, restConfig, log)
 
 	return &k8sConnHandler, nil
@@ -0,0 +1,137 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package connection
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// DefaultBreakerFailureThreshold is the number of consecutive failed requests to a remote cluster opening its circuit breaker
+	DefaultBreakerFailureThreshold = 5
+	// DefaultBreakerOpenDuration is the time the circuit breaker of a remote cluster rejects the requests before trying again
+	DefaultBreakerOpenDuration = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned for the requests to a remote cluster whose circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreaker stops the requests to a remote cluster after FailureThreshold consecutive failures.
+// Once OpenDuration has elapsed, a single trial request is let through, closing the breaker if it succeeds.
+// A breaker is shared by all the controllers using the same RemoteK8sConnHandler
+type CircuitBreaker struct {
+	FailureThreshold int
+	OpenDuration     time.Duration
+
+	lock      sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+// NewCircuitBreaker returns a circuit breaker with the default threshold and open duration
+func NewCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{
+		FailureThreshold: DefaultBreakerFailureThreshold,
+		OpenDuration:     DefaultBreakerOpenDuration,
+	}
+}
+
+// IsOpen returns true if the breaker currently rejects the requests
+func (b *CircuitBreaker) IsOpen() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.failures >= b.FailureThreshold
+}
+
+// allow returns true if a request can be sent, marking it as the trial request once the breaker is half-open
+func (b *CircuitBreaker) allow(now time.Time) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.failures < b.FailureThreshold {
+		return true
+	}
+	if now.Before(b.openUntil) || b.trial {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+// record updates the breaker with the outcome of a request and returns true if the breaker is open
+func (b *CircuitBreaker) record(failed bool, now time.Time) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		return false
+	}
+	b.failures++
+	if b.failures >= b.FailureThreshold {
+		b.openUntil = now.Add(b.OpenDuration)
+		return true
+	}
+	return false
+}
+
+// release lets another trial request through without recording an outcome, e.g. for cancelled requests
+func (b *CircuitBreaker) release() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.trial = false
+}
+
+// breakerRoundTripper rejects the requests to a remote cluster while its circuit breaker is open
+type breakerRoundTripper struct {
+	rt        http.RoundTripper
+	breaker   *CircuitBreaker
+	clusterID string
+}
+
+func (b *breakerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !b.breaker.allow(time.Now()) {
+		remoteRequestsRejected.WithLabelValues(b.clusterID).Inc()
+		return nil, fmt.Errorf("%w for ClusterId: %s", ErrCircuitOpen, b.clusterID)
+	}
+	resp, err := b.rt.RoundTrip(req)
+	if req.Context().Err() != nil {
+		// The caller gave up on the request, which says nothing about the remote cluster
+		b.breaker.release()
+		return resp, err
+	}
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+	if b.breaker.record(failed, time.Now()) {
+		remoteCircuitBreakerOpen.WithLabelValues(b.clusterID).Set(1)
+	} else {
+		remoteCircuitBreakerOpen.WithLabelValues(b.clusterID).Set(0)
+	}
+	return resp, err
+}
+
+// withCircuitBreaker returns a copy of the config whose requests go through the circuit breaker
+func withCircuitBreaker(config *rest.Config, clusterID string, breaker *CircuitBreaker) *rest.Config {
+	config = rest.CopyConfig(config)
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &breakerRoundTripper{rt: rt, breaker: breaker, clusterID: clusterID}
+	})
+	return config
+}
@@ -0,0 +1,86 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package connection
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := &CircuitBreaker{FailureThreshold: 2, OpenDuration: time.Minute}
+	now := time.Now()
+
+	assert.True(t, breaker.allow(now))
+	assert.False(t, breaker.record(true, now))
+	assert.True(t, breaker.allow(now))
+	assert.True(t, breaker.record(true, now))
+	assert.True(t, breaker.IsOpen())
+
+	// Open: the requests are rejected until the open duration has elapsed
+	assert.False(t, breaker.allow(now.Add(30*time.Second)))
+
+	// Half-open: a single trial request is let through
+	later := now.Add(2 * time.Minute)
+	assert.True(t, breaker.allow(later))
+	assert.False(t, breaker.allow(later))
+
+	// A failed trial opens the breaker again
+	assert.True(t, breaker.record(true, later))
+	assert.False(t, breaker.allow(later.Add(30*time.Second)))
+
+	// A successful trial closes it
+	evenLater := later.Add(2 * time.Minute)
+	assert.True(t, breaker.allow(evenLater))
+	assert.False(t, breaker.record(false, evenLater))
+	assert.False(t, breaker.IsOpen())
+	assert.True(t, breaker.allow(evenLater))
+}
+
+func TestRemoteK8sConnHandler_GetConnectionCircuitBreaker(t *testing.T) {
+	clusterID := "breaker-cluster"
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	k8sConnHandler := &RemoteK8sConnHandler{}
+	k8sConnHandler.AddOrUpdateConfig(clusterID, &rest.Config{Host: server.URL}, logr.Discard())
+	connection, err := k8sConnHandler.GetConnection(clusterID)
+	assert.NoError(t, err)
+
+	rejected := testutil.ToFloat64(remoteRequestsRejected.WithLabelValues(clusterID))
+	for i := 0; i < DefaultBreakerFailureThreshold+3; i++ {
+		_, err = connection.GetReplicationGroup(context.Background(), "rg-1")
+		assert.Error(t, err)
+	}
+
+	// Once open, the breaker rejects the requests without sending them to the remote cluster
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.LessOrEqual(t, int(requests.Load()), DefaultBreakerFailureThreshold+1)
+	assert.Less(t, rejected, testutil.ToFloat64(remoteRequestsRejected.WithLabelValues(clusterID)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(remoteCircuitBreakerOpen.WithLabelValues(clusterID)))
+}
@@ -0,0 +1,104 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package connection
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dell/csm-replication/pkg/common"
+	"k8s.io/client-go/rest"
+)
+
+// Features of the replication controller and repctl writing to the clusters.
+// They identify the workflow of each write in the audit logs of the cluster
+const (
+	// FeatureRGSync syncs the DellCSIReplicationGroups to the remote cluster
+	FeatureRGSync = "rg-sync"
+	// FeaturePVSync syncs the PersistentVolumes to the remote cluster
+	FeaturePVSync = "pv-sync"
+	// FeaturePVCSync syncs the PersistentVolumeClaim annotations to the remote cluster
+	FeaturePVCSync = "pvc-sync"
+	// FeatureSnapshotReplication creates the snapshots of the RGs on the remote cluster
+	FeatureSnapshotReplication = "snapshot-replication"
+	// FeatureDeletion requests the deletion of the remote objects
+	FeatureDeletion = "deletion"
+	// FeatureRepair repairs the pairing annotations of the remote RGs
+	FeatureRepair = "repair"
+	// FeatureFailover requests the failover of the RGs
+	FeatureFailover = "failover"
+	// FeatureFailback requests the failback of the RGs
+	FeatureFailback = "failback"
+	// FeatureReprotect requests the reprotect of the RGs
+	FeatureReprotect = "reprotect"
+	// FeatureSwap requests the swap of the RGs
+	FeatureSwap = "swap"
+	// FeatureMaintenance requests the maintenance actions of the RGs, e.g. suspend, resume and sync
+	FeatureMaintenance = "maintenance"
+)
+
+type featureContextKey struct{}
+
+// WithFeature returns a copy of the context tagging the remote writes made with it with the feature
+func WithFeature(ctx context.Context, feature string) context.Context {
+	return context.WithValue(ctx, featureContextKey{}, feature)
+}
+
+// FeatureFromContext returns the feature the context is tagged with, empty if none
+func FeatureFromContext(ctx context.Context) string {
+	feature, _ := ctx.Value(featureContextKey{}).(string)
+	return feature
+}
+
+// FieldManager returns the field manager of the remote writes of the replication controller made with the context
+func FieldManager(ctx context.Context) string {
+	return NamedFieldManager(ctx, common.DellReplicationController)
+}
+
+// NamedFieldManager returns the field manager of the writes made by the manager with the context
+func NamedFieldManager(ctx context.Context, manager string) string {
+	if feature := FeatureFromContext(ctx); feature != "" {
+		return manager + "/" + feature
+	}
+	return manager
+}
+
+// featureRoundTripper appends the feature of the request context to its user agent
+type featureRoundTripper struct {
+	rt http.RoundTripper
+}
+
+func (f *featureRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	feature := FeatureFromContext(req.Context())
+	if feature == "" {
+		return f.rt.RoundTrip(req)
+	}
+	// RoundTrippers must not modify the request
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", req.Header.Get("User-Agent")+" feature/"+feature)
+	return f.rt.RoundTrip(req)
+}
+
+// WithFeatureUserAgent returns a copy of the config whose requests carry the feature of their context in the user agent
+func WithFeatureUserAgent(config *rest.Config) *rest.Config {
+	config = rest.CopyConfig(config)
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &featureRoundTripper{rt: rt}
+	})
+	return config
+}
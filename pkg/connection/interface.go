@@ -29,6 +29,7 @@ type RemoteClusterClient interface {
 	GetStorageClass(ctx context.Context, storageClassName string) (*storageV1.StorageClass, error)
 	ListStorageClass(ctx context.Context) (*storageV1.StorageClassList, error)
 	CreateStorageClass(ctx context.Context, storageClass *storageV1.StorageClass) error
+	ListStorageCapacities(ctx context.Context) (*storageV1.CSIStorageCapacityList, error)
 	ListCustomResourceDefinitions(ctx context.Context) (*apiExtensionsv1.CustomResourceDefinitionList, error)
 	GetCustomResourceDefinitions(ctx context.Context, crdName string) (*apiExtensionsv1.CustomResourceDefinition, error)
 	GetPersistentVolume(ctx context.Context, persistentVolumeName string) (*corev1.PersistentVolume, error)
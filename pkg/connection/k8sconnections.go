@@ -37,6 +37,23 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
 
+var (
+	remoteScheme     *runtime.Scheme
+	remoteSchemeOnce sync.Once
+)
+
+// getRemoteScheme returns the scheme shared by all the remote cluster clients
+func getRemoteScheme() *runtime.Scheme {
+	remoteSchemeOnce.Do(func() {
+		remoteScheme = runtime.NewScheme()
+		utilruntime.Must(clientgoscheme.AddToScheme(remoteScheme))
+		utilruntime.Must(repv1.AddToScheme(remoteScheme))
+		utilruntime.Must(apiExtensionsv1.AddToScheme(remoteScheme))
+		utilruntime.Must(s1.AddToScheme(remoteScheme))
+	})
+	return remoteScheme
+}
+
 // RemoteK8sConnHandler handler of remote Kubernetes cluster connection
 type RemoteK8sConnHandler struct {
 	configs       map[string]*rest.Config
@@ -65,6 +82,7 @@ func (k8sConnHandler *RemoteK8sConnHandler) AddOrUpdateConfig(clusterID string,
 		if _, ok := k8sConnHandler.cachedClients[clusterID]; ok {
 			log.V(common.DebugLevel).Info(fmt.Sprintf("Deleting cached client for ClusterId: %s", clusterID))
 			delete(k8sConnHandler.cachedClients, clusterID)
+			remoteClients.Dec()
 		}
 	} else {
 		log.V(common.InfoLevel).Info(fmt.Sprintf("Adding REST config for ClusterId: %s\n", clusterID))
@@ -84,15 +102,14 @@ func (k8sConnHandler *RemoteK8sConnHandler) getControllerClient(clusterID string
 	// First check if we have cached the client already
 	if client, ok := k8sConnHandler.cachedClients[clusterID]; ok {
 		log.Printf("Using cached client for ClusterId: %s\n", clusterID)
+		remoteClientLookups.WithLabelValues(clusterID, "reused").Inc()
 		return client, nil
 	}
-	scheme := runtime.NewScheme()
-	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
-	utilruntime.Must(repv1.AddToScheme(scheme))
-	utilruntime.Must(apiExtensionsv1.AddToScheme(scheme))
-	utilruntime.Must(s1.AddToScheme(scheme))
+	remoteClientLookups.WithLabelValues(clusterID, "created").Inc()
 	if clientConfig, ok := k8sConnHandler.configs[clusterID]; ok {
-		client, err := GetControllerClient(clientConfig, scheme)
+		// The client, and so its circuit breaker, is shared by all the controllers using the handler
+		clientConfig = withCircuitBreaker(WithFeatureUserAgent(clientConfig), clusterID, NewCircuitBreaker())
+		client, err := GetControllerClient(clientConfig, getRemoteScheme())
 		if err != nil {
 			return nil, err
 		}
@@ -101,6 +118,7 @@ func (k8sConnHandler *RemoteK8sConnHandler) getControllerClient(clusterID string
 			Client:    client,
 		}
 		k8sConnHandler.cachedClients[clusterID] = &remoteK8sClient
+		remoteClients.Inc()
 		return &remoteK8sClient, nil
 	}
 	return nil, fmt.Errorf("clusterID - %s not found", clusterID)
@@ -176,7 +194,17 @@ func (c *RemoteK8sControllerClient) ListStorageClass(ctx context.Context) (*stor
 
 // CreateStorageClass creates storage class object in current cluster
 func (c *RemoteK8sControllerClient) CreateStorageClass(ctx context.Context, storageClass *storageV1.StorageClass) error {
-	return c.Client.Create(ctx, storageClass)
+	return c.Client.Create(ctx, storageClass, ctrlClient.FieldOwner(FieldManager(ctx)))
+}
+
+// ListStorageCapacities returns list of all CSI storage capacity objects that are currently in cluster
+func (c *RemoteK8sControllerClient) ListStorageCapacities(ctx context.Context) (*storageV1.CSIStorageCapacityList, error) {
+	capacityList := &storageV1.CSIStorageCapacityList{}
+	err := c.Client.List(ctx, capacityList)
+	if err != nil {
+		return nil, err
+	}
+	return capacityList, nil
 }
 
 // GetPersistentVolume returns persistent volume object by querying cluster using persistent volume name
@@ -191,12 +219,12 @@ func (c *RemoteK8sControllerClient) GetPersistentVolume(ctx context.Context, per
 
 // CreatePersistentVolume creates persistent volume object in current cluster
 func (c *RemoteK8sControllerClient) CreatePersistentVolume(ctx context.Context, volume *corev1.PersistentVolume) error {
-	return c.Client.Create(ctx, volume)
+	return c.Client.Create(ctx, volume, ctrlClient.FieldOwner(FieldManager(ctx)))
 }
 
 // UpdatePersistentVolume updates persistent volume object in current cluster
 func (c *RemoteK8sControllerClient) UpdatePersistentVolume(ctx context.Context, volume *corev1.PersistentVolume) error {
-	return c.Client.Update(ctx, volume)
+	return c.Client.Update(ctx, volume, ctrlClient.FieldOwner(FieldManager(ctx)))
 }
 
 // GetReplicationGroup returns replication group object by querying cluster using replication group name
@@ -211,12 +239,12 @@ func (c *RemoteK8sControllerClient) GetReplicationGroup(ctx context.Context, rep
 
 // UpdateReplicationGroup updates replication group object in current cluster
 func (c *RemoteK8sControllerClient) UpdateReplicationGroup(ctx context.Context, replicationGroup *repv1.DellCSIReplicationGroup) error {
-	return c.Client.Update(ctx, replicationGroup)
+	return c.Client.Update(ctx, replicationGroup, ctrlClient.FieldOwner(FieldManager(ctx)))
 }
 
 // CreateReplicationGroup creates replication group object in current cluster
 func (c *RemoteK8sControllerClient) CreateReplicationGroup(ctx context.Context, group *repv1.DellCSIReplicationGroup) error {
-	return c.Client.Create(ctx, group)
+	return c.Client.Create(ctx, group, ctrlClient.FieldOwner(FieldManager(ctx)))
 }
 
 // ListReplicationGroup returns list of all replication group objects that are currently in cluster
@@ -261,17 +289,17 @@ func (c *RemoteK8sControllerClient) GetPersistentVolumeClaim(ctx context.Context
 
 // UpdatePersistentVolumeClaim updates persistent volume claim object in current cluster
 func (c *RemoteK8sControllerClient) UpdatePersistentVolumeClaim(ctx context.Context, claim *corev1.PersistentVolumeClaim) error {
-	return c.Client.Update(ctx, claim)
+	return c.Client.Update(ctx, claim, ctrlClient.FieldOwner(FieldManager(ctx)))
 }
 
 // CreateSnapshotContent creates the snapshot content on the remote cluster
 func (c *RemoteK8sControllerClient) CreateSnapshotContent(ctx context.Context, content *s1.VolumeSnapshotContent) error {
-	return c.Client.Create(ctx, content)
+	return c.Client.Create(ctx, content, ctrlClient.FieldOwner(FieldManager(ctx)))
 }
 
 // CreateSnapshotObject creates the snapshot on the remote cluster
 func (c *RemoteK8sControllerClient) CreateSnapshotObject(ctx context.Context, content *s1.VolumeSnapshot) error {
-	return c.Client.Create(ctx, content)
+	return c.Client.Create(ctx, content, ctrlClient.FieldOwner(FieldManager(ctx)))
 }
 
 // GetSnapshotClass returns snapshot class object by querying cluster using snapshot class name.
@@ -287,7 +315,7 @@ func (c *RemoteK8sControllerClient) GetSnapshotClass(ctx context.Context, snapCl
 
 // CreateNamespace creates a desired namespace on the remote cluster.
 func (c *RemoteK8sControllerClient) CreateNamespace(ctx context.Context, content *corev1.Namespace) error {
-	return c.Client.Create(ctx, content)
+	return c.Client.Create(ctx, content, ctrlClient.FieldOwner(FieldManager(ctx)))
 }
 
 // GetNamespace returns the desired namespace from the remote cluster.
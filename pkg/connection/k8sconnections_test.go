@@ -3,12 +3,15 @@ package connection
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	repv1 "github.com/dell/csm-replication/api/v1"
 	"github.com/go-logr/logr"
 	s1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	storageV1 "k8s.io/api/storage/v1"
@@ -18,7 +21,9 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 func initScheme() *runtime.Scheme {
@@ -54,6 +59,26 @@ func TestRemoteK8sConnHandler_GetConnection(t *testing.T) {
 	assert.NotNil(t, connection)
 }
 
+func TestRemoteK8sConnHandler_GetConnectionLookupMetrics(t *testing.T) {
+	clusterID := "metrics-cluster"
+
+	k8sConnHandler := &RemoteK8sConnHandler{}
+	k8sConnHandler.AddOrUpdateConfig(clusterID, &rest.Config{Host: "https://example.com"}, logr.Discard())
+
+	reused := testutil.ToFloat64(remoteClientLookups.WithLabelValues(clusterID, "reused"))
+	created := testutil.ToFloat64(remoteClientLookups.WithLabelValues(clusterID, "created"))
+
+	first, err := k8sConnHandler.GetConnection(clusterID)
+	assert.NoError(t, err)
+	second, err := k8sConnHandler.GetConnection(clusterID)
+	assert.NoError(t, err)
+
+	// The second lookup must reuse the client constructed by the first one
+	assert.Same(t, first, second)
+	assert.Equal(t, created+1, testutil.ToFloat64(remoteClientLookups.WithLabelValues(clusterID, "created")))
+	assert.Equal(t, reused+1, testutil.ToFloat64(remoteClientLookups.WithLabelValues(clusterID, "reused")))
+}
+
 func TestRemoteK8sConnHandler_GetConnectionNotFound(t *testing.T) {
 	clusterID := "test-cluster"
 
@@ -329,6 +354,23 @@ func TestRemoteK8sControllerClient_StorageClass(t *testing.T) {
 	assert.NotNil(t, resultList)
 }
 
+func TestRemoteK8sControllerClient_ListStorageCapacities(t *testing.T) {
+	capacity := &storageV1.CSIStorageCapacity{
+		ObjectMeta:       metav1.ObjectMeta{Name: "test-capacity", Namespace: "test-ns"},
+		StorageClassName: "test-sc",
+	}
+
+	scheme := initScheme()
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(capacity).Build()
+	controllerClient := &RemoteK8sControllerClient{
+		Client: client,
+	}
+
+	resultList, err := controllerClient.ListStorageCapacities(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, resultList.Items, 1)
+}
+
 func TestRemoteK8sControllerClient_PersistentVolume(t *testing.T) {
 	pv := &corev1.PersistentVolume{
 		ObjectMeta: metav1.ObjectMeta{
@@ -383,6 +425,65 @@ func TestRemoteK8sControllerClient_ReplicationGroup(t *testing.T) {
 	assert.NotNil(t, resultList)
 }
 
+func TestRemoteK8sControllerClient_FieldManager(t *testing.T) {
+	var fieldManagers []string
+	client := fake.NewClientBuilder().WithScheme(initScheme()).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, client ctrlClient.WithWatch, obj ctrlClient.Object, opts ...ctrlClient.CreateOption) error {
+			createOpts := &ctrlClient.CreateOptions{}
+			createOpts.ApplyOptions(opts)
+			fieldManagers = append(fieldManagers, createOpts.FieldManager)
+			return client.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, client ctrlClient.WithWatch, obj ctrlClient.Object, opts ...ctrlClient.UpdateOption) error {
+			updateOpts := &ctrlClient.UpdateOptions{}
+			updateOpts.ApplyOptions(opts)
+			fieldManagers = append(fieldManagers, updateOpts.FieldManager)
+			return client.Update(ctx, obj, opts...)
+		},
+	}).Build()
+	controllerClient := &RemoteK8sControllerClient{Client: client}
+
+	rg := &repv1.DellCSIReplicationGroup{ObjectMeta: metav1.ObjectMeta{Name: "test-rg"}}
+	err := controllerClient.CreateReplicationGroup(WithFeature(context.TODO(), FeatureRGSync), rg)
+	assert.NoError(t, err)
+	err = controllerClient.UpdateReplicationGroup(WithFeature(context.TODO(), FeatureDeletion), rg)
+	assert.NoError(t, err)
+	err = controllerClient.CreateNamespace(context.TODO(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"dell-replication-controller/rg-sync",
+		"dell-replication-controller/deletion",
+		"dell-replication-controller",
+	}, fieldManagers)
+}
+
+func TestFeatureUserAgent(t *testing.T) {
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.UserAgent())
+	}))
+	defer server.Close()
+
+	config := &rest.Config{Host: server.URL, UserAgent: "dell-replication-controller/v1"}
+	httpClient, err := rest.HTTPClientFor(WithFeatureUserAgent(config))
+	assert.NoError(t, err)
+	assert.Nil(t, config.WrapTransport, "the config is not modified")
+
+	for _, ctx := range []context.Context{WithFeature(context.TODO(), FeatureSnapshotReplication), context.TODO()} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, nil)
+		assert.NoError(t, err)
+		resp, err := httpClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, []string{
+		"dell-replication-controller/v1 feature/snapshot-replication",
+		"dell-replication-controller/v1",
+	}, userAgents)
+}
+
 func TestRemoteK8sControllerClient_GetPersistentVolumeClaim(t *testing.T) {
 	pvc := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
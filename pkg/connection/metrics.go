@@ -0,0 +1,50 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package connection
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// remoteClientLookups counts the lookups of remote cluster clients, by whether an existing client was reused.
+	// The remote clients read and write directly to the API server of the remote cluster, they are not backed by informer caches
+	remoteClientLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "replication_remote_client_lookups_total",
+		Help: "Number of remote cluster client lookups, by result (reused or created)",
+	}, []string{"cluster_id", "result"})
+	// remoteClients reports the number of remote cluster clients currently held by the connection handler
+	remoteClients = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "replication_remote_clients",
+		Help: "Number of remote cluster clients currently held by the connection handler",
+	})
+	// remoteCircuitBreakerOpen reports whether the circuit breaker of a remote cluster is open
+	remoteCircuitBreakerOpen = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "replication_remote_circuit_breaker_open",
+		Help: "Whether the circuit breaker of the remote cluster is open (1) or closed (0)",
+	}, []string{"cluster_id"})
+	// remoteRequestsRejected counts the requests to a remote cluster rejected by its open circuit breaker
+	remoteRequestsRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "replication_remote_requests_rejected_total",
+		Help: "Number of requests to the remote cluster rejected by its open circuit breaker",
+	}, []string{"cluster_id"})
+)
+
+func init() {
+	// Register with the controller-runtime registry, so that the metrics are
+	// served by the manager's metrics endpoint along with the controller metrics
+	metrics.Registry.MustRegister(remoteClientLookups, remoteClients, remoteCircuitBreakerOpen, remoteRequestsRejected)
+}
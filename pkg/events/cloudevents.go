@@ -0,0 +1,194 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cesql "github.com/cloudevents/sdk-go/sql/v2"
+	cesqlparser "github.com/cloudevents/sdk-go/sql/v2/parser"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	repv1 "github.com/dell/csm-replication/api/v1"
+	"github.com/dell/csm-replication/pkg/common"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+)
+
+const (
+	// TypeRGCreated is emitted once a DellCSIReplicationGroup has been created for a new protection group
+	TypeRGCreated = "com.dell.replication.rg.created"
+	// TypeRGSynced is emitted once a DellCSIReplicationGroup has been synced to the remote cluster
+	TypeRGSynced = "com.dell.replication.rg.synced"
+	// TypeRGActionStarted is emitted when a replication action starts executing
+	TypeRGActionStarted = "com.dell.replication.rg.action.started"
+	// TypeRGActionCompleted is emitted when a replication action succeeds or fails with a final error
+	TypeRGActionCompleted = "com.dell.replication.rg.action.completed"
+	// TypeRGDeleted is emitted once a DellCSIReplicationGroup and its protection group have been deleted
+	TypeRGDeleted = "com.dell.replication.rg.deleted"
+
+	// defaultQueueSize number of events buffered while the sink is slow or unavailable
+	defaultQueueSize = 1000
+	// sendTimeout timeout of a single delivery to the sink
+	sendTimeout = 10 * time.Second
+)
+
+// Emitter emits the lifecycle events of the DellCSIReplicationGroups
+type Emitter interface {
+	Emit(eventType string, rg *repv1.DellCSIReplicationGroup, data map[string]string)
+}
+
+// Emit emits the event using the emitter, if it is set
+func Emit(emitter Emitter, eventType string, rg *repv1.DellCSIReplicationGroup, data map[string]string) {
+	if emitter != nil {
+		emitter.Emit(eventType, rg, data)
+	}
+}
+
+// RGEventData is the payload of the lifecycle events
+type RGEventData struct {
+	Name              string            `json:"name"`
+	DriverName        string            `json:"driverName,omitempty"`
+	RemoteClusterID   string            `json:"remoteClusterId,omitempty"`
+	ProtectionGroupID string            `json:"protectionGroupId,omitempty"`
+	State             string            `json:"state,omitempty"`
+	LinkState         string            `json:"linkState,omitempty"`
+	Details           map[string]string `json:"details,omitempty"`
+}
+
+// CloudEventEmitter delivers the lifecycle events as CloudEvents to an HTTP sink.
+// The events are queued & delivered in the background, so that a slow or unavailable sink doesn't block the reconcilers
+type CloudEventEmitter struct {
+	Log    logr.Logger
+	client cloudevents.Client
+	sink   string
+	source string
+	filter cesql.Expression
+	queue  chan cloudevents.Event
+}
+
+// NewCloudEventEmitter returns new CloudEventEmitter for the sink.
+// If the filter is set, only the events for which the CESQL expression evaluates to true are delivered
+func NewCloudEventEmitter(log logr.Logger, sink, source, filter string) (*CloudEventEmitter, error) {
+	client, err := cloudevents.NewClientHTTP()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloudevents client: %s", err.Error())
+	}
+	return newCloudEventEmitter(log, client, sink, source, filter)
+}
+
+func newCloudEventEmitter(log logr.Logger, client cloudevents.Client, sink, source, filter string) (*CloudEventEmitter, error) {
+	emitter := &CloudEventEmitter{
+		Log:    log,
+		client: client,
+		sink:   sink,
+		source: source,
+		queue:  make(chan cloudevents.Event, defaultQueueSize),
+	}
+	if filter != "" {
+		expression, err := parseFilter(filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CESQL filter %q: %s", filter, err.Error())
+		}
+		emitter.filter = expression
+	}
+	return emitter, nil
+}
+
+func parseFilter(filter string) (expression cesql.Expression, err error) {
+	// The CESQL parser panics on some malformed expressions
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return cesqlparser.Parse(filter)
+}
+
+// Emit queues the event for delivery, if it matches the filter. Events are dropped if the queue is full
+func (e *CloudEventEmitter) Emit(eventType string, rg *repv1.DellCSIReplicationGroup, data map[string]string) {
+	event, err := e.newEvent(eventType, rg, data)
+	if err != nil {
+		e.Log.Error(err, "Failed to build cloud event", "type", eventType, "rg", rg.Name)
+		return
+	}
+	if !e.matches(event) {
+		return
+	}
+	select {
+	case e.queue <- event:
+	default:
+		e.Log.V(common.InfoLevel).Info("Cloud event queue is full, dropping event", "type", eventType, "rg", rg.Name)
+	}
+}
+
+func (e *CloudEventEmitter) newEvent(eventType string, rg *repv1.DellCSIReplicationGroup, data map[string]string) (cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	event.SetID(uuid.New().String())
+	event.SetType(eventType)
+	event.SetSource(e.source)
+	event.SetSubject(rg.Name)
+	event.SetTime(time.Now())
+	event.SetExtension("driver", rg.Spec.DriverName)
+	event.SetExtension("remotecluster", rg.Spec.RemoteClusterID)
+	if action, ok := data["action"]; ok {
+		event.SetExtension("action", action)
+	}
+	err := event.SetData(cloudevents.ApplicationJSON, RGEventData{
+		Name:              rg.Name,
+		DriverName:        rg.Spec.DriverName,
+		RemoteClusterID:   rg.Spec.RemoteClusterID,
+		ProtectionGroupID: rg.Spec.ProtectionGroupID,
+		State:             rg.Status.State,
+		LinkState:         rg.Status.ReplicationLinkState.State,
+		Details:           data,
+	})
+	return event, err
+}
+
+func (e *CloudEventEmitter) matches(event cloudevents.Event) bool {
+	if e.filter == nil {
+		return true
+	}
+	result, err := e.filter.Evaluate(event)
+	if err != nil {
+		// CESQL evaluation errors are raised for the missing attributes, the result is the default value of the type
+		e.Log.V(common.DebugLevel).Info("CESQL filter evaluation error", "error", err.Error(), "type", event.Type())
+	}
+	matched, ok := result.(bool)
+	return ok && matched
+}
+
+// Start delivers the queued events until the context is cancelled. Implements manager.Runnable
+func (e *CloudEventEmitter) Start(ctx context.Context) error {
+	ctx = cloudevents.ContextWithTarget(ctx, e.sink)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-e.queue:
+			e.send(ctx, event)
+		}
+	}
+}
+
+func (e *CloudEventEmitter) send(ctx context.Context, event cloudevents.Event) {
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	if result := e.client.Send(sendCtx, event); !cloudevents.IsACK(result) {
+		e.Log.Error(result, "Failed to deliver cloud event", "type", event.Type(), "subject", event.Subject())
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	repv1 "github.com/dell/csm-replication/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getRG() *repv1.DellCSIReplicationGroup {
+	return &repv1.DellCSIReplicationGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "rg-1"},
+		Spec: repv1.DellCSIReplicationGroupSpec{
+			DriverName:        "csi-fake",
+			RemoteClusterID:   "remote-123",
+			ProtectionGroupID: "pg-1",
+		},
+		Status: repv1.DellCSIReplicationGroupStatus{State: "Ready"},
+	}
+}
+
+func TestCloudEventEmitter(t *testing.T) {
+	received := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	emitter, err := NewCloudEventEmitter(logr.Discard(), server.URL, "/dell-csi-replicator/cluster-1",
+		"type = 'com.dell.replication.rg.action.completed' AND action = 'FAILOVER_REMOTE'")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = emitter.Start(ctx)
+	}()
+
+	rg := getRG()
+	Emit(emitter, TypeRGCreated, rg, nil)
+	Emit(emitter, TypeRGActionCompleted, rg, map[string]string{"action": "REPROTECT_LOCAL"})
+	Emit(emitter, TypeRGActionCompleted, rg, map[string]string{"action": "FAILOVER_REMOTE", "result": "succeeded"})
+
+	select {
+	case req := <-received:
+		assert.Equal(t, TypeRGActionCompleted, req.Header.Get("ce-type"))
+		assert.Equal(t, "/dell-csi-replicator/cluster-1", req.Header.Get("ce-source"))
+		assert.Equal(t, "rg-1", req.Header.Get("ce-subject"))
+		assert.Equal(t, "remote-123", req.Header.Get("ce-remotecluster"))
+		var data RGEventData
+		assert.NoError(t, json.Unmarshal(<-bodies, &data))
+		assert.Equal(t, "pg-1", data.ProtectionGroupID)
+		assert.Equal(t, "succeeded", data.Details["result"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("cloud event was not delivered")
+	}
+
+	select {
+	case req := <-received:
+		t.Fatalf("unexpected cloud event %s delivered", req.Header.Get("ce-type"))
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestCloudEventEmitterInvalidFilter(t *testing.T) {
+	_, err := NewCloudEventEmitter(logr.Discard(), "http://localhost", "/source", "type = ")
+	assert.Error(t, err)
+}
+
+func TestEmitWithoutEmitter(t *testing.T) {
+	assert.NotPanics(t, func() {
+		Emit(nil, TypeRGDeleted, getRG(), nil)
+	})
+}
@@ -0,0 +1,98 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package fips enforces FIPS validated crypto for the TLS connections of the replication components.
+// The FIPS validated crypto module is only available in the binaries built with GOEXPERIMENT=boringcrypto
+package fips
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"k8s.io/client-go/rest"
+)
+
+var (
+	// CipherSuites FIPS approved TLS 1.2 cipher suites
+	CipherSuites = []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	}
+	// CurvePreferences FIPS approved elliptic curves
+	CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+
+	errNotEnabled = errors.New("binary is not built with the FIPS validated crypto module, build it with GOEXPERIMENT=boringcrypto")
+)
+
+// Enabled returns true if the binary is built with the FIPS validated crypto module
+func Enabled() bool {
+	return cryptoModuleEnabled()
+}
+
+// Verify returns an error if the binary is not built with the FIPS validated crypto module
+func Verify() error {
+	if !Enabled() {
+		return errNotEnabled
+	}
+	return nil
+}
+
+// ConfigureTLS restricts the TLS config to the FIPS approved versions, cipher suites and curves
+func ConfigureTLS(cfg *tls.Config) {
+	cfg.MinVersion = tls.VersionTLS12
+	cfg.CipherSuites = CipherSuites
+	cfg.CurvePreferences = CurvePreferences
+}
+
+// NewHTTPClient returns an HTTP client whose TLS connections are restricted to the FIPS approved versions, cipher suites and curves
+func NewHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{} // #nosec G402 -- restricted by ConfigureTLS
+	}
+	ConfigureTLS(transport.TLSClientConfig)
+	return &http.Client{Transport: transport}
+}
+
+// VerifyRESTConfig returns an error if the connection to the cluster is not using verified TLS
+func VerifyRESTConfig(clusterID string, config *rest.Config) error {
+	if config.Insecure {
+		return fmt.Errorf("TLS verification is disabled for ClusterId: %s", clusterID)
+	}
+	server, _, err := rest.DefaultServerUrlFor(config)
+	if err != nil {
+		return fmt.Errorf("invalid server of ClusterId: %s. error - %s", clusterID, err.Error())
+	}
+	if server.Scheme != "https" {
+		return fmt.Errorf("server %s of ClusterId: %s is not using TLS", server.String(), clusterID)
+	}
+	return nil
+}
+
+// VerifyURL returns an error if the endpoint is not using TLS
+func VerifyURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("endpoint %s is not using TLS", endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package fips
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+)
+
+func TestVerify(t *testing.T) {
+	assert.Equal(t, Enabled(), Verify() == nil)
+}
+
+func TestConfigureTLS(t *testing.T) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS10} // #nosec G402
+	ConfigureTLS(cfg)
+	assert.Equal(t, uint16(tls.VersionTLS12), cfg.MinVersion)
+	assert.Equal(t, CipherSuites, cfg.CipherSuites)
+	assert.Equal(t, CurvePreferences, cfg.CurvePreferences)
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	client := NewHTTPClient()
+	transport, ok := client.Transport.(*http.Transport)
+	assert.True(t, ok)
+	assert.Equal(t, uint16(tls.VersionTLS12), transport.TLSClientConfig.MinVersion)
+	assert.Equal(t, CipherSuites, transport.TLSClientConfig.CipherSuites)
+	assert.NotSame(t, http.DefaultTransport, client.Transport, "Default transport is left untouched")
+}
+
+func TestVerifyRESTConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *rest.Config
+		wantErr bool
+	}{
+		{"https", &rest.Config{Host: "https://10.0.0.1:6443"}, false},
+		{"default scheme with CA", &rest.Config{Host: "10.0.0.1:6443", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")}}, false},
+		{"http", &rest.Config{Host: "http://10.0.0.1:8080"}, true},
+		{"default scheme without TLS", &rest.Config{Host: "10.0.0.1:8080"}, true},
+		{"insecure", &rest.Config{Host: "https://10.0.0.1:6443", TLSClientConfig: rest.TLSClientConfig{Insecure: true}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyRESTConfig("cluster-1", tt.config)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestVerifyURL(t *testing.T) {
+	assert.NoError(t, VerifyURL("https://sink.example.com/events"))
+	assert.Error(t, VerifyURL("http://sink.example.com/events"))
+	assert.Error(t, VerifyURL("://"))
+}
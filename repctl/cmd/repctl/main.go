@@ -96,6 +96,8 @@ func main() {
 	repctl.AddCommand(cmd.GetEditCommand())
 	repctl.AddCommand(cmd.GetMigrateCommand())
 	repctl.AddCommand(cmd.GetSnapshotCommand())
+	repctl.AddCommand(cmd.GetImportCommand())
+	repctl.AddCommand(cmd.GetRepairCommand())
 
 	err := repctl.Execute()
 	if err != nil {
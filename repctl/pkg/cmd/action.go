@@ -22,6 +22,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	repv1 "github.com/dell/csm-replication/api/v1"
+	"github.com/dell/csm-replication/pkg/connection"
 	"github.com/dell/repctl/pkg/config"
 	"github.com/dell/repctl/pkg/k8s"
 	"github.com/spf13/cobra"
@@ -77,7 +78,7 @@ This command will perform a maintenance on current source site. repctl will patc
 						log.Printf("found RG (%s) on cluster (%s), updating spec...", rg.Name, cluster.GetID())
 					}
 					rg.Spec.Action = action
-					if err := cluster.UpdateReplicationGroup(context.Background(), rg); err != nil {
+					if err := cluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureMaintenance), rg); err != nil {
 						log.Fatalf("exec: error executing action %s", err.Error())
 					}
 					log.Printf("RG (%s) on cluster (%s), successfully updated with action: (%s)", rg.Name, cluster.GetID(), action)
@@ -17,6 +17,7 @@ package cmd
 import (
 	"context"
 
+	"github.com/dell/csm-replication/pkg/connection"
 	"github.com/dell/repctl/pkg/k8s"
 
 	"github.com/dell/repctl/pkg/config"
@@ -104,7 +105,7 @@ func failbackToRG(configFolder, rgName string, discard, verbose bool, wait bool)
 	if verbose {
 		log.Print("updating spec...")
 	}
-	if err := cluster.UpdateReplicationGroup(context.Background(), rg); err != nil {
+	if err := cluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureFailback), rg); err != nil {
 		log.Fatalf("failback: error executing UpdateAction %s\n", err.Error())
 	}
 	if wait {
@@ -157,7 +158,7 @@ func failbackToCluster(configFolder, inputSourceCluster, rgName string, discard,
 	if verbose {
 		log.Printf("found RG (%s) on source cluster, updating spec...\n", rg.Name)
 	}
-	if err := sourceCluster.UpdateReplicationGroup(context.Background(), rg); err != nil {
+	if err := sourceCluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureFailback), rg); err != nil {
 		log.Fatalf("failback: error executing UpdateAction %s\n", err.Error())
 	}
 	if wait {
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	repv1 "github.com/dell/csm-replication/api/v1"
+	"github.com/dell/csm-replication/pkg/connection"
 	"github.com/dell/repctl/pkg/config"
 	"github.com/dell/repctl/pkg/k8s"
 	log "github.com/sirupsen/logrus"
@@ -140,7 +141,7 @@ func failoverToRG(configFolder, rgName string, unplanned bool, verbose bool, wai
 		if verbose {
 			log.Print("found flag for unplanned failover, updating remote RG...")
 		}
-		if err := cluster.UpdateReplicationGroup(context.Background(), rg); err != nil {
+		if err := cluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureFailover), rg); err != nil {
 			log.Fatalf("failover: error executing UpdateAction %s\n", err.Error())
 		}
 		if wait {
@@ -175,7 +176,7 @@ func failoverToRG(configFolder, rgName string, unplanned bool, verbose bool, wai
 			log.Fatal("Aborted. One of your RGs is in error state. Please verify RGs logs/events and try again.")
 		}
 		sourceRG.Spec.Action = config.ActionFailoverRemote
-		if err := cluster.UpdateReplicationGroup(context.Background(), sourceRG); err != nil {
+		if err := cluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureFailover), sourceRG); err != nil {
 			log.Fatalf("failover: error executing UpdateAction %s\n", err.Error())
 		}
 		if wait {
@@ -225,7 +226,7 @@ func failoverToCluster(configFolder, inputTargetCluster, rgName string, unplanne
 		if verbose {
 			log.Print("found flag for unplanned failover, updating remote RG...")
 		}
-		if err := targetCluster.UpdateReplicationGroup(context.Background(), rg); err != nil {
+		if err := targetCluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureFailover), rg); err != nil {
 			log.Fatalf("failover: error executing UpdateAction %s\n", err.Error())
 		}
 		if wait {
@@ -265,7 +266,7 @@ func failoverToCluster(configFolder, inputTargetCluster, rgName string, unplanne
 			log.Fatal("Aborted. One of your RGs is in error state. Please verify RGs logs/events and try again.")
 		}
 		rg.Spec.Action = config.ActionFailoverRemote
-		if err := sourceCluster.UpdateReplicationGroup(context.Background(), rg); err != nil {
+		if err := sourceCluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureFailover), rg); err != nil {
 			log.Fatalf("failover: error executing UpdateAction %s\n", err.Error())
 		}
 		if wait {
@@ -0,0 +1,554 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/dell/repctl/pkg/config"
+	"github.com/dell/repctl/pkg/display"
+	"github.com/dell/repctl/pkg/k8s"
+	"github.com/dell/repctl/pkg/metadata"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiTypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	importFromVelero   = "velero"
+	importFromVolSync  = "volsync"
+	importFromExternal = "external"
+
+	// importedFrom annotation referencing the object the storage class was generated from
+	importedFrom = "importedFrom"
+	// importedSchedule annotation containing the schedule of the imported object
+	importedSchedule = "importedSchedule"
+
+	// stepReprotect volume is provisioned by the driver and needs array-level re-protection
+	stepReprotect = "reprotect"
+	// stepCopy volume is provisioned by another driver and its data needs to be copied to the driver
+	stepCopy = "copy"
+	// stepPending volume is not bound yet
+	stepPending = "pending"
+	// stepNone volume is already replicated
+	stepNone = "none"
+)
+
+var (
+	veleroScheduleListGVK    = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "ScheduleList"}
+	volSyncSourceListGVK     = schema.GroupVersionKind{Group: "volsync.backube", Version: "v1alpha1", Kind: "ReplicationSourceList"}
+	volumeReplicationListGVK = schema.GroupVersionKind{Group: "replication.storage.openshift.io", Version: "v1alpha1", Kind: "VolumeReplicationList"}
+)
+
+// ImportedVolume represents a volume protected by the imported tool and the step required to replicate it
+type ImportedVolume struct {
+	Name      string `display:"PVC"`
+	Namespace string `display:"Namespace"`
+	PVName    string `display:"PV"`
+	SCName    string `display:"SC"`
+	RGName    string `display:"RG"`
+	TargetSC  string `display:"Target SC"`
+	Step      string `display:"Step"`
+}
+
+// ImportPlan is the migration plan for the volumes protected by the imported tool
+type ImportPlan struct {
+	ClusterID string
+	// StorageClasses replication enabled storage classes the volumes to re-protect are migrated to.
+	// The driver creates the protection groups, and their replication groups, when the volumes are migrated
+	StorageClasses []storagev1.StorageClass
+	Volumes        []ImportedVolume
+}
+
+// Print prints the volumes of the plan as a table, followed by the commands re-protecting them
+func (p *ImportPlan) Print(out io.Writer) {
+	t, err := display.NewTableWriter(ImportedVolume{}, out)
+	if err != nil {
+		return
+	}
+	t.PrintHeader()
+	for _, obj := range p.Volumes {
+		t.PrintRow(obj)
+	}
+	t.Done()
+
+	for _, volume := range p.Volumes {
+		if volume.Step == stepReprotect {
+			_, _ = fmt.Fprintf(out, "./repctl --clusters %s migrate pvc %s -n %s --to-sc %s\n",
+				p.ClusterID, volume.Name, volume.Namespace, volume.TargetSC)
+		}
+	}
+}
+
+// Manifests returns the generated storage classes as multi-document YAML
+func (p *ImportPlan) Manifests() ([]byte, error) {
+	var buf bytes.Buffer
+	for i := range p.StorageClasses {
+		data, err := yaml.Marshal(&p.StorageClasses[i])
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString(yamlSeparator[1:] + "\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// importOptions are the parameters of the storage classes generated by the import
+type importOptions struct {
+	from            string
+	namespace       string
+	driver          string
+	remoteClusterID string
+	prefix          string
+	// toSC existing replication enabled storage class the volumes are migrated to, no storage class is generated if set
+	toSC string
+}
+
+// importGroup is a set of claims protected together by the imported tool
+type importGroup struct {
+	name     string
+	source   string
+	schedule string
+	claims   []apiTypes.NamespacedName
+}
+
+// GetImportCommand returns 'import' cobra command
+/* #nosec G104 */
+func GetImportCommand() *cobra.Command {
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "generate replication configuration from the objects of other replication and backup tools",
+		Example: `
+./repctl --clusters <cluster-id> --driver <driver-name> import --from velero|volsync|external --remote-cluster-id <remote-cluster-id> (--to-sc <scName>) (-n <namespace>) (-o <output-file>)`,
+		Long: `
+This command inspects the velero schedules, volsync replication sources or external (csi-addons) volume replications
+and generates a replication enabled StorageClass for each group of volumes protected together, along with a migration plan
+listing which volumes need array-level re-protection. The generated StorageClasses are based on the StorageClasses of the volumes
+and have to be completed with the driver specific replication parameters before being created on the source and remote clusters.
+Volumes marked 'reprotect' are re-protected with the listed 'repctl migrate pvc --to-sc' commands, the driver then creates
+their replication groups. Volumes marked 'copy' are provisioned by another driver and their data has to be copied.
+The migration plan is printed to stderr, the StorageClasses to stdout unless an output file is specified.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			opts := importOptions{
+				from:            viper.GetString("import-from"),
+				namespace:       viper.GetString("import-namespace"),
+				driver:          viper.GetString(config.Driver),
+				remoteClusterID: viper.GetString("import-remote-cluster-id"),
+				prefix:          viper.GetString(config.ReplicationPrefix),
+				toSC:            viper.GetString("import-to-sc"),
+			}
+			output := viper.GetString("import-output")
+			if opts.driver == "" {
+				log.Fatalf("import: driver name is required, use --driver flag")
+			}
+
+			configFolder, err := getClustersFolderPath("/.repctl/clusters/")
+			if err != nil {
+				log.Fatalf("import: error getting clusters folder path: %s", err.Error())
+			}
+
+			mc := &k8s.MultiClusterConfigurator{}
+			clusters, err := mc.GetAllClusters(viper.GetStringSlice(config.Clusters), configFolder)
+			if err != nil {
+				log.Fatalf("import: error in initializing cluster info: %s", err.Error())
+			}
+
+			var manifests [][]byte
+			for _, cluster := range clusters.Clusters {
+				plan, err := importFrom(context.Background(), cluster, opts)
+				if err != nil {
+					log.Fatalf("import: error importing from %s in cluster %s: %s", opts.from, cluster.GetID(), err.Error())
+				}
+				log.Infof("Migration plan for cluster %s:", cluster.GetID())
+				plan.Print(os.Stderr)
+
+				data, err := plan.Manifests()
+				if err != nil {
+					log.Fatalf("import: error generating storage classes: %s", err.Error())
+				}
+				if len(data) > 0 {
+					manifests = append(manifests, data)
+				}
+			}
+
+			data := bytes.Join(manifests, []byte(yamlSeparator[1:]+"\n"))
+			if output == "" {
+				fmt.Print(string(data))
+				return
+			}
+			if err := os.WriteFile(filepath.Clean(output), data, 0o600); err != nil {
+				log.Fatalf("import: error writing storage classes to %s: %s", output, err.Error())
+			}
+			log.Infof("Storage classes written to %s", output)
+		},
+	}
+
+	importCmd.Flags().String("from", "", "tool to import from (velero, volsync or external)")
+	_ = viper.BindPFlag("import-from", importCmd.Flags().Lookup("from"))
+	importCmd.Flags().StringP("namespace", "n", "", "import only the volumes of the namespace")
+	_ = viper.BindPFlag("import-namespace", importCmd.Flags().Lookup("namespace"))
+	importCmd.Flags().String("remote-cluster-id", "", "remote cluster id of the generated storage classes")
+	_ = viper.BindPFlag("import-remote-cluster-id", importCmd.Flags().Lookup("remote-cluster-id"))
+	importCmd.Flags().String("to-sc", "", "existing replication enabled StorageClass to re-protect the volumes to, instead of generating StorageClasses")
+	_ = viper.BindPFlag("import-to-sc", importCmd.Flags().Lookup("to-sc"))
+	importCmd.Flags().StringP("output", "o", "", "file to write the generated storage classes to (default stdout)")
+	_ = viper.BindPFlag("import-output", importCmd.Flags().Lookup("output"))
+	err := importCmd.MarkFlagRequired("from")
+	if err != nil {
+		log.Fatalf(" error in marking flag from required %s", err.Error())
+	}
+	return importCmd
+}
+
+// importFrom inspects the objects of the imported tool and generates the migration plan for its volumes
+func importFrom(ctx context.Context, cluster k8s.ClusterInterface, opts importOptions) (*ImportPlan, error) {
+	var groups []importGroup
+	var err error
+	switch opts.from {
+	case importFromVelero:
+		groups, err = getVeleroGroups(ctx, cluster, opts.namespace)
+	case importFromVolSync:
+		groups, err = getVolSyncGroups(ctx, cluster, opts.namespace)
+	case importFromExternal:
+		groups, err = getExternalGroups(ctx, cluster, opts.namespace)
+	default:
+		return nil, fmt.Errorf("unsupported source %q, must be one of %s, %s, %s",
+			opts.from, importFromVelero, importFromVolSync, importFromExternal)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buildImportPlan(ctx, cluster, groups, opts)
+}
+
+func listImportObjects(ctx context.Context, cluster k8s.ClusterInterface, gvk schema.GroupVersionKind, namespace string) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk)
+	err := cluster.GetClient().List(ctx, list, client.InNamespace(namespace))
+	if meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("%s is not installed in the cluster: %s", gvk.GroupKind().String(), err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// getVeleroGroups returns a group per velero schedule, containing the claims backed up by the schedule,
+// i.e. the claims of its namespaces matching its label selectors
+func getVeleroGroups(ctx context.Context, cluster k8s.ClusterInterface, namespace string) ([]importGroup, error) {
+	schedules, err := listImportObjects(ctx, cluster, veleroScheduleListGVK, "")
+	if err != nil {
+		return nil, err
+	}
+	claims, err := cluster.ListPersistentVolumeClaims(ctx, client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []importGroup
+	for _, schedule := range schedules {
+		cron, _, _ := unstructured.NestedString(schedule.Object, "spec", "schedule")
+		included, _, _ := unstructured.NestedStringSlice(schedule.Object, "spec", "template", "includedNamespaces")
+		excluded, _, _ := unstructured.NestedStringSlice(schedule.Object, "spec", "template", "excludedNamespaces")
+		selectors, err := getVeleroLabelSelectors(&schedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector in velero schedule %s/%s: %s", schedule.GetNamespace(), schedule.GetName(), err.Error())
+		}
+
+		group := importGroup{
+			name:     importFromVelero + "-" + schedule.GetName(),
+			source:   path.Join(importFromVelero, schedule.GetNamespace(), schedule.GetName()),
+			schedule: cron,
+		}
+		for _, claim := range claims.Items {
+			if isNamespaceIncluded(claim.Namespace, included, excluded) && matchesAnySelector(claim.Labels, selectors) {
+				group.claims = append(group.claims, apiTypes.NamespacedName{Namespace: claim.Namespace, Name: claim.Name})
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// getVeleroLabelSelectors returns the label selectors of the schedule, at least one of which must match the
+// backed up objects. Velero accepts either a single labelSelector or a list of orLabelSelectors
+func getVeleroLabelSelectors(schedule *unstructured.Unstructured) ([]labels.Selector, error) {
+	var selectors []labels.Selector
+	if selector, ok, _ := unstructured.NestedMap(schedule.Object, "spec", "template", "labelSelector"); ok {
+		parsed, err := parseLabelSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, parsed)
+	}
+	orSelectors, _, _ := unstructured.NestedSlice(schedule.Object, "spec", "template", "orLabelSelectors")
+	for _, selector := range orSelectors {
+		selectorMap, ok := selector.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("orLabelSelectors must be a list of label selectors")
+		}
+		parsed, err := parseLabelSelector(selectorMap)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, parsed)
+	}
+	return selectors, nil
+}
+
+func parseLabelSelector(selector map[string]interface{}) (labels.Selector, error) {
+	labelSelector := new(metav1.LabelSelector)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(selector, labelSelector); err != nil {
+		return nil, err
+	}
+	return metav1.LabelSelectorAsSelector(labelSelector)
+}
+
+// matchesAnySelector returns true if any of the selectors matches the labels, or if there is no selector
+func matchesAnySelector(objectLabels map[string]string, selectors []labels.Selector) bool {
+	if len(selectors) == 0 {
+		return true
+	}
+	for _, selector := range selectors {
+		if selector.Matches(labels.Set(objectLabels)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNamespaceIncluded(namespace string, included, excluded []string) bool {
+	for _, ns := range excluded {
+		if ns == namespace {
+			return false
+		}
+	}
+	if len(included) == 0 {
+		return true
+	}
+	for _, ns := range included {
+		if ns == "*" || ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// getVolSyncGroups returns a group per volsync replication source, containing its source claim
+func getVolSyncGroups(ctx context.Context, cluster k8s.ClusterInterface, namespace string) ([]importGroup, error) {
+	sources, err := listImportObjects(ctx, cluster, volSyncSourceListGVK, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []importGroup
+	for _, source := range sources {
+		sourcePVC, _, _ := unstructured.NestedString(source.Object, "spec", "sourcePVC")
+		cron, _, _ := unstructured.NestedString(source.Object, "spec", "trigger", "schedule")
+		group := importGroup{
+			name:     importFromVolSync + "-" + source.GetNamespace() + "-" + source.GetName(),
+			source:   path.Join(importFromVolSync, source.GetNamespace(), source.GetName()),
+			schedule: cron,
+		}
+		if sourcePVC != "" {
+			group.claims = append(group.claims, apiTypes.NamespacedName{Namespace: source.GetNamespace(), Name: sourcePVC})
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// getExternalGroups returns a group per namespace and volume replication class of the csi-addons volume replications
+func getExternalGroups(ctx context.Context, cluster k8s.ClusterInterface, namespace string) ([]importGroup, error) {
+	replications, err := listImportObjects(ctx, cluster, volumeReplicationListGVK, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []importGroup
+	index := make(map[string]int)
+	for _, replication := range replications {
+		kind, _, _ := unstructured.NestedString(replication.Object, "spec", "dataSource", "kind")
+		name, _, _ := unstructured.NestedString(replication.Object, "spec", "dataSource", "name")
+		class, _, _ := unstructured.NestedString(replication.Object, "spec", "volumeReplicationClass")
+		if kind != "PersistentVolumeClaim" || name == "" {
+			log.Warnf("Skipping volume replication %s/%s, only PersistentVolumeClaim data sources are supported",
+				replication.GetNamespace(), replication.GetName())
+			continue
+		}
+
+		groupName := importFromExternal + "-" + replication.GetNamespace() + "-" + class
+		i, ok := index[groupName]
+		if !ok {
+			i = len(groups)
+			index[groupName] = i
+			groups = append(groups, importGroup{
+				name:   groupName,
+				source: path.Join(importFromExternal, replication.GetNamespace(), class),
+			})
+		}
+		groups[i].claims = append(groups[i].claims, apiTypes.NamespacedName{Namespace: replication.GetNamespace(), Name: name})
+	}
+	return groups, nil
+}
+
+// buildImportPlan determines the migration step of the claims of the groups. A replication enabled storage class
+// is generated for every group and storage class of the volumes to re-protect, so that the volumes protected together
+// by the imported tool are migrated to the same storage class. A claim protected by several groups is only
+// planned once, with the first group by name
+func buildImportPlan(ctx context.Context, cluster k8s.ClusterInterface, groups []importGroup, opts importOptions) (*ImportPlan, error) {
+	plan := &ImportPlan{ClusterID: cluster.GetID()}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].name < groups[j].name })
+
+	generated := make(map[string]bool)
+	planned := make(map[apiTypes.NamespacedName]string)
+	for _, group := range groups {
+		for _, name := range group.claims {
+			if plannedBy, ok := planned[name]; ok {
+				if plannedBy != group.source {
+					log.Warnf("Skipping %s of %s, already planned with %s", name.String(), group.source, plannedBy)
+				}
+				continue
+			}
+			planned[name] = group.source
+
+			claim, err := cluster.GetPersistentVolumeClaim(ctx, name.Namespace, name.Name)
+			if errors.IsNotFound(err) {
+				log.Warnf("Skipping %s of %s, claim not found", name.String(), group.source)
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			volume, err := getImportedVolume(ctx, cluster, claim, opts.driver)
+			if err != nil {
+				return nil, err
+			}
+			if volume.Step == stepReprotect {
+				volume.TargetSC = opts.toSC
+				if volume.TargetSC == "" {
+					volume.TargetSC = group.name + "-" + volume.SCName
+					if !generated[volume.TargetSC] {
+						sc, err := newImportedStorageClass(ctx, cluster, volume.TargetSC, volume.SCName, group, opts)
+						if err != nil {
+							return nil, err
+						}
+						plan.StorageClasses = append(plan.StorageClasses, *sc)
+						generated[volume.TargetSC] = true
+					}
+				}
+			}
+			plan.Volumes = append(plan.Volumes, volume)
+		}
+	}
+	return plan, nil
+}
+
+func getImportedVolume(ctx context.Context, cluster k8s.ClusterInterface, claim *v1.PersistentVolumeClaim, driver string) (ImportedVolume, error) {
+	volume := ImportedVolume{
+		Name:      claim.Name,
+		Namespace: claim.Namespace,
+		PVName:    claim.Spec.VolumeName,
+	}
+	if claim.Spec.StorageClassName != nil {
+		volume.SCName = *claim.Spec.StorageClassName
+	}
+
+	if existingRG := claim.Annotations[metadata.ReplicationGroup]; existingRG != "" {
+		volume.RGName = existingRG
+		volume.Step = stepNone
+		return volume, nil
+	}
+	if claim.Spec.VolumeName == "" {
+		volume.Step = stepPending
+		return volume, nil
+	}
+
+	pv, err := cluster.GetPersistentVolume(ctx, claim.Spec.VolumeName)
+	if err != nil {
+		return volume, err
+	}
+	if pv.Spec.CSI != nil && pv.Spec.CSI.Driver == driver {
+		volume.Step = stepReprotect
+	} else {
+		volume.Step = stepCopy
+	}
+	return volume, nil
+}
+
+// newImportedStorageClass returns a replication enabled copy of the storage class of the volumes of the group.
+// The remote storage class has the same name, it's created on the remote cluster from the same manifest
+func newImportedStorageClass(ctx context.Context, cluster k8s.ClusterInterface, name, sourceSC string,
+	group importGroup, opts importOptions,
+) (*storagev1.StorageClass, error) {
+	source := new(storagev1.StorageClass)
+	if err := cluster.GetClient().Get(ctx, apiTypes.NamespacedName{Name: sourceSC}, source); err != nil {
+		return nil, fmt.Errorf("error getting storage class %s: %s", sourceSC, err.Error())
+	}
+
+	sc := &storagev1.StorageClass{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: storagev1.SchemeGroupVersion.String(),
+			Kind:       "StorageClass",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				path.Join(opts.prefix, importedFrom): group.source,
+			},
+		},
+		Provisioner:          opts.driver,
+		Parameters:           make(map[string]string),
+		ReclaimPolicy:        source.ReclaimPolicy,
+		MountOptions:         source.MountOptions,
+		AllowVolumeExpansion: source.AllowVolumeExpansion,
+		VolumeBindingMode:    source.VolumeBindingMode,
+		AllowedTopologies:    source.AllowedTopologies,
+	}
+	for k, v := range source.Parameters {
+		sc.Parameters[k] = v
+	}
+	sc.Parameters[metadata.ReplicationEnabled] = "true"
+	sc.Parameters[metadata.RemoteSCName] = name
+	if opts.remoteClusterID != "" {
+		sc.Parameters[metadata.RemoteClusterID] = opts.remoteClusterID
+	}
+	if group.schedule != "" {
+		sc.Annotations[path.Join(opts.prefix, importedSchedule)] = group.schedule
+	}
+	return sc, nil
+}
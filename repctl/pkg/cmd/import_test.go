@@ -0,0 +1,227 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/dell/repctl/pkg/k8s"
+	"github.com/dell/repctl/pkg/metadata"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const importDriver = "csi-powerstore.dellemc.com"
+
+type ImportTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ImportTestSuite) SetupSuite() {
+	metadata.Init("replication.storage.dell.com")
+}
+
+func (suite *ImportTestSuite) getCluster(objects ...client.Object) k8s.ClusterInterface {
+	scheme := runtime.NewScheme()
+	suite.NoError(v1.AddToScheme(scheme))
+	suite.NoError(storagev1.AddToScheme(scheme))
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(v1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"), meta.RESTScopeNamespace)
+	mapper.Add(v1.SchemeGroupVersion.WithKind("PersistentVolume"), meta.RESTScopeRoot)
+	mapper.Add(storagev1.SchemeGroupVersion.WithKind("StorageClass"), meta.RESTScopeRoot)
+	for _, gvk := range []schema.GroupVersionKind{veleroScheduleListGVK, volSyncSourceListGVK} {
+		gvk.Kind = gvk.Kind[:len(gvk.Kind)-len("List")]
+		mapper.Add(gvk, meta.RESTScopeNamespace)
+	}
+
+	cluster := &k8s.Cluster{ClusterID: "cluster-1"}
+	cluster.SetClient(fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(objects...).Build())
+	return cluster
+}
+
+func getImportClaim(namespace, name, pvName, driver string) (*v1.PersistentVolumeClaim, *v1.PersistentVolume) {
+	sc := "sc-" + driver
+	claim := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       v1.PersistentVolumeClaimSpec{StorageClassName: &sc, VolumeName: pvName},
+	}
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: pvName},
+		Spec: v1.PersistentVolumeSpec{
+			PersistentVolumeSource: v1.PersistentVolumeSource{CSI: &v1.CSIPersistentVolumeSource{Driver: driver}},
+		},
+	}
+	return claim, pv
+}
+
+func (suite *ImportTestSuite) TestImportFromVelero() {
+	schedule := &unstructured.Unstructured{}
+	schedule.SetGroupVersionKind(schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "Schedule"})
+	schedule.SetNamespace("velero")
+	schedule.SetName("daily")
+	suite.NoError(unstructured.SetNestedField(schedule.Object, "0 1 * * *", "spec", "schedule"))
+	suite.NoError(unstructured.SetNestedStringSlice(schedule.Object, []string{"app"}, "spec", "template", "includedNamespaces"))
+
+	claim1, pv1 := getImportClaim("app", "data-1", "pv-1", importDriver)
+	claim2, pv2 := getImportClaim("app", "data-2", "pv-2", "ebs.csi.aws.com")
+	claim3, pv3 := getImportClaim("other", "data-3", "pv-3", importDriver)
+	sc := &storagev1.StorageClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: "sc-" + importDriver},
+		Provisioner: importDriver,
+		Parameters:  map[string]string{"arrayID": "PS000000000001"},
+	}
+	cluster := suite.getCluster(schedule, claim1, pv1, claim2, pv2, claim3, pv3, sc)
+
+	opts := importOptions{
+		from:            importFromVelero,
+		driver:          importDriver,
+		remoteClusterID: "cluster-2",
+		prefix:          "replication.storage.dell.com",
+	}
+	plan, err := importFrom(context.Background(), cluster, opts)
+	suite.NoError(err)
+	targetSC := "velero-daily-sc-" + importDriver
+	suite.Equal([]ImportedVolume{
+		{Name: "data-1", Namespace: "app", PVName: "pv-1", SCName: "sc-" + importDriver, TargetSC: targetSC, Step: stepReprotect},
+		{Name: "data-2", Namespace: "app", PVName: "pv-2", SCName: "sc-ebs.csi.aws.com", Step: stepCopy},
+	}, plan.Volumes)
+
+	suite.Len(plan.StorageClasses, 1)
+	repSC := plan.StorageClasses[0]
+	suite.Equal(targetSC, repSC.Name)
+	suite.Equal(importDriver, repSC.Provisioner)
+	suite.Equal(map[string]string{
+		"arrayID":                   "PS000000000001",
+		metadata.ReplicationEnabled: "true",
+		metadata.RemoteSCName:       targetSC,
+		metadata.RemoteClusterID:    "cluster-2",
+	}, repSC.Parameters)
+	suite.Equal("velero/velero/daily", repSC.Annotations["replication.storage.dell.com/importedFrom"])
+	suite.Equal("0 1 * * *", repSC.Annotations["replication.storage.dell.com/importedSchedule"])
+
+	manifests, err := plan.Manifests()
+	suite.NoError(err)
+	suite.Contains(string(manifests), "kind: StorageClass")
+	suite.NotContains(string(manifests), "DellCSIReplicationGroup")
+
+	var out bytes.Buffer
+	plan.Print(&out)
+	suite.Contains(out.String(), "migrate pvc data-1 -n app --to-sc "+targetSC)
+}
+
+func (suite *ImportTestSuite) TestImportFromVeleroWithLabelSelectors() {
+	newSchedule := func(name string) *unstructured.Unstructured {
+		schedule := &unstructured.Unstructured{}
+		schedule.SetGroupVersionKind(schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "Schedule"})
+		schedule.SetNamespace("velero")
+		schedule.SetName(name)
+		return schedule
+	}
+	// Both schedules back up data-1, only the daily schedule backs up data-2 and none backs up data-3
+	daily := newSchedule("daily")
+	suite.NoError(unstructured.SetNestedStringMap(daily.Object, map[string]string{"tier": "db"}, "spec", "template", "labelSelector", "matchLabels"))
+	hourly := newSchedule("hourly")
+	suite.NoError(unstructured.SetNestedSlice(hourly.Object, []interface{}{
+		map[string]interface{}{"matchLabels": map[string]interface{}{"app": "orders"}},
+		map[string]interface{}{"matchExpressions": []interface{}{
+			map[string]interface{}{"key": "critical", "operator": "Exists"},
+		}},
+	}, "spec", "template", "orLabelSelectors"))
+
+	claim1, pv1 := getImportClaim("app", "data-1", "pv-1", importDriver)
+	claim1.Labels = map[string]string{"tier": "db", "app": "orders"}
+	claim2, pv2 := getImportClaim("app", "data-2", "pv-2", importDriver)
+	claim2.Labels = map[string]string{"tier": "db"}
+	claim3, pv3 := getImportClaim("app", "data-3", "pv-3", importDriver)
+	claim3.Labels = map[string]string{"tier": "web"}
+	cluster := suite.getCluster(daily, hourly, claim1, pv1, claim2, pv2, claim3, pv3)
+
+	plan, err := importFrom(context.Background(), cluster, importOptions{from: importFromVelero, driver: importDriver, toSC: "replicated"})
+	suite.NoError(err)
+	suite.Equal([]ImportedVolume{
+		{Name: "data-1", Namespace: "app", PVName: "pv-1", SCName: "sc-" + importDriver, TargetSC: "replicated", Step: stepReprotect},
+		{Name: "data-2", Namespace: "app", PVName: "pv-2", SCName: "sc-" + importDriver, TargetSC: "replicated", Step: stepReprotect},
+	}, plan.Volumes)
+}
+
+func (suite *ImportTestSuite) TestImportFromVeleroWithInvalidLabelSelector() {
+	schedule := &unstructured.Unstructured{}
+	schedule.SetGroupVersionKind(schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "Schedule"})
+	schedule.SetNamespace("velero")
+	schedule.SetName("daily")
+	suite.NoError(unstructured.SetNestedSlice(schedule.Object, []interface{}{
+		map[string]interface{}{"key": "tier", "operator": "Unknown"},
+	}, "spec", "template", "labelSelector", "matchExpressions"))
+
+	_, err := importFrom(context.Background(), suite.getCluster(schedule), importOptions{from: importFromVelero, driver: importDriver})
+	suite.ErrorContains(err, "invalid label selector in velero schedule velero/daily")
+}
+
+func (suite *ImportTestSuite) TestImportToExistingStorageClass() {
+	source := &unstructured.Unstructured{}
+	source.SetGroupVersionKind(schema.GroupVersionKind{Group: "volsync.backube", Version: "v1alpha1", Kind: "ReplicationSource"})
+	source.SetNamespace("app")
+	source.SetName("db")
+	suite.NoError(unstructured.SetNestedField(source.Object, "db-data", "spec", "sourcePVC"))
+
+	claim, pv := getImportClaim("app", "db-data", "pv-1", importDriver)
+	cluster := suite.getCluster(source, claim, pv)
+
+	plan, err := importFrom(context.Background(), cluster, importOptions{from: importFromVolSync, driver: importDriver, toSC: "replicated"})
+	suite.NoError(err)
+	suite.Len(plan.Volumes, 1)
+	suite.Equal(stepReprotect, plan.Volumes[0].Step)
+	suite.Equal("replicated", plan.Volumes[0].TargetSC)
+	suite.Empty(plan.StorageClasses, "No storage class is generated")
+}
+
+func (suite *ImportTestSuite) TestImportFromVolSync() {
+	source := &unstructured.Unstructured{}
+	source.SetGroupVersionKind(schema.GroupVersionKind{Group: "volsync.backube", Version: "v1alpha1", Kind: "ReplicationSource"})
+	source.SetNamespace("app")
+	source.SetName("db")
+	suite.NoError(unstructured.SetNestedField(source.Object, "db-data", "spec", "sourcePVC"))
+
+	claim, pv := getImportClaim("app", "db-data", "pv-1", importDriver)
+	claim.Annotations = map[string]string{metadata.ReplicationGroup: "rg-existing"}
+	cluster := suite.getCluster(source, claim, pv)
+
+	plan, err := importFrom(context.Background(), cluster, importOptions{from: importFromVolSync, driver: importDriver})
+	suite.NoError(err)
+	suite.Len(plan.Volumes, 1)
+	suite.Equal(stepNone, plan.Volumes[0].Step)
+	suite.Equal("rg-existing", plan.Volumes[0].RGName)
+	suite.Empty(plan.StorageClasses)
+}
+
+func (suite *ImportTestSuite) TestImportFromUnsupportedSource() {
+	_, err := importFrom(context.Background(), suite.getCluster(), importOptions{from: "unknown"})
+	suite.ErrorContains(err, "unsupported source")
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
@@ -0,0 +1,112 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dell/csm-replication/pkg/connection"
+	"github.com/dell/repctl/pkg/config"
+	"github.com/dell/repctl/pkg/k8s"
+	"github.com/dell/repctl/pkg/metadata"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// repairForced is the value of the repair annotation which lets the replication controller repair
+// the annotations of a remote RG which points back to another RG paired with it
+const repairForced = "force"
+
+// GetRepairCommand returns 'repair' cobra command
+func GetRepairCommand() *cobra.Command {
+	repairCmd := &cobra.Command{
+		Use:   "repair",
+		Short: "allows to repair the annotations of a half-synced replication group",
+		Example: `
+For single or multi-cluster config:
+./repctl --rg <rg-id> repair
+
+To repair the RG even if its remote RG points back to another RG:
+./repctl --rg <rg-id> repair --force`,
+		Long: `
+This command requests the replication controller to rebuild the pairing annotations of the specified RG from its remote RG.
+It repairs the RGs which are synced without the name of their remote RG, or whose sync to the remote cluster has been interrupted.
+repctl will annotate the RG on every cluster it is found on, the outcome is reported in the events of the RG.
+The annotations of a remote RG pointing back to another RG paired with it are only overwritten with --force.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			rgName := viper.GetString(config.ReplicationGroup)
+			verbose := viper.GetBool(config.Verbose)
+			force := viper.GetBool("repair-force")
+			if rgName == "" {
+				log.Fatalf("repair: wrong input, no input provided. Replication Group ID is needed.")
+			}
+
+			configFolder, err := getClustersFolderPath("/.repctl/clusters/")
+			if err != nil {
+				log.Fatalf("repair: error getting clusters folder path: %s", err.Error())
+			}
+			if verbose {
+				log.Printf("reading cluster configs...")
+			}
+			mc := &k8s.MultiClusterConfigurator{}
+			clusters, err := mc.GetAllClusters([]string{}, configFolder)
+			if err != nil {
+				log.Fatalf("repair: error in initializing cluster info: %s", err.Error())
+			}
+
+			repaired, err := requestRepair(connection.WithFeature(context.Background(), connection.FeatureRepair), clusters.Clusters, rgName, force)
+			if err != nil {
+				log.Fatalf("repair: %s", err.Error())
+			}
+			for _, clusterID := range repaired {
+				log.Printf("RG (%s) on cluster (%s), repair successfully requested", rgName, clusterID)
+			}
+		},
+	}
+	repairCmd.Flags().Bool("force", false, "repair even if the remote RG points back to another RG")
+	_ = viper.BindPFlag("repair-force", repairCmd.Flags().Lookup("force"))
+
+	return repairCmd
+}
+
+// requestRepair annotates the RG on every cluster it is found on and returns the IDs of these clusters
+func requestRepair(ctx context.Context, clusters []k8s.ClusterInterface, rgName string, force bool) ([]string, error) {
+	value := "yes"
+	if force {
+		value = repairForced
+	}
+	var repaired []string
+	for _, cluster := range clusters {
+		rg, err := cluster.GetReplicationGroups(ctx, rgName)
+		if err != nil {
+			// skip the cluster on which RG with rgName is not found
+			continue
+		}
+		if rg.Annotations == nil {
+			rg.Annotations = make(map[string]string)
+		}
+		rg.Annotations[metadata.RepairRequested] = value
+		if err := cluster.UpdateReplicationGroup(ctx, rg); err != nil {
+			return repaired, fmt.Errorf("error updating RG (%s) on cluster (%s): %s", rgName, cluster.GetID(), err.Error())
+		}
+		repaired = append(repaired, cluster.GetID())
+	}
+	if len(repaired) == 0 {
+		return nil, fmt.Errorf("no cluster found with RG (%s)", rgName)
+	}
+	return repaired, nil
+}
@@ -0,0 +1,86 @@
+/*
+ Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	repv1 "github.com/dell/csm-replication/api/v1"
+	"github.com/dell/repctl/pkg/k8s"
+	"github.com/dell/repctl/pkg/metadata"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type RepairTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RepairTestSuite) SetupSuite() {
+	metadata.Init("replication.storage.dell.com")
+}
+
+func (suite *RepairTestSuite) getCluster(clusterID string, objects ...client.Object) k8s.ClusterInterface {
+	scheme := runtime.NewScheme()
+	suite.NoError(repv1.AddToScheme(scheme))
+
+	cluster := &k8s.Cluster{ClusterID: clusterID}
+	cluster.SetClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build())
+	return cluster
+}
+
+func (suite *RepairTestSuite) TestRequestRepair() {
+	ctx := context.Background()
+	source := suite.getCluster("cluster-1", &repv1.DellCSIReplicationGroup{ObjectMeta: metav1.ObjectMeta{Name: "rg-1"}})
+	target := suite.getCluster("cluster-2", &repv1.DellCSIReplicationGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "rg-1", Annotations: map[string]string{metadata.RemoteClusterID: "cluster-1"}},
+	})
+	other := suite.getCluster("cluster-3")
+
+	repaired, err := requestRepair(ctx, []k8s.ClusterInterface{source, target, other}, "rg-1", false)
+	suite.NoError(err)
+	suite.Equal([]string{"cluster-1", "cluster-2"}, repaired)
+
+	for _, cluster := range []k8s.ClusterInterface{source, target} {
+		rg, err := cluster.GetReplicationGroups(ctx, "rg-1")
+		suite.NoError(err)
+		suite.Equal("yes", rg.Annotations["replication.storage.dell.com/repairRequested"], cluster.GetID())
+	}
+}
+
+func (suite *RepairTestSuite) TestRequestForcedRepair() {
+	ctx := context.Background()
+	cluster := suite.getCluster("cluster-1", &repv1.DellCSIReplicationGroup{ObjectMeta: metav1.ObjectMeta{Name: "rg-1"}})
+
+	_, err := requestRepair(ctx, []k8s.ClusterInterface{cluster}, "rg-1", true)
+	suite.NoError(err)
+
+	rg, err := cluster.GetReplicationGroups(ctx, "rg-1")
+	suite.NoError(err)
+	suite.Equal("force", rg.Annotations["replication.storage.dell.com/repairRequested"])
+}
+
+func (suite *RepairTestSuite) TestRequestRepairWithoutRG() {
+	_, err := requestRepair(context.Background(), []k8s.ClusterInterface{suite.getCluster("cluster-1")}, "rg-1", false)
+	suite.ErrorContains(err, "no cluster found with RG (rg-1)")
+}
+
+func TestRepairTestSuite(t *testing.T) {
+	suite.Run(t, new(RepairTestSuite))
+}
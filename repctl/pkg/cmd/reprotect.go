@@ -17,6 +17,7 @@ package cmd
 import (
 	"context"
 
+	"github.com/dell/csm-replication/pkg/connection"
 	"github.com/dell/repctl/pkg/config"
 	"github.com/dell/repctl/pkg/k8s"
 	log "github.com/sirupsen/logrus"
@@ -126,7 +127,7 @@ func reprotectAtRG(configFolder, rgName string, verbose bool, wait bool) {
 		log.Fatal("Aborted. One of your RGs is in error state. Please verify RGs logs/events and try again.")
 	}
 	rg.Spec.Action = config.ActionReprotect
-	if err := cluster.UpdateReplicationGroup(context.Background(), rg); err != nil {
+	if err := cluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureReprotect), rg); err != nil {
 		log.Fatalf("reprotect: error executing UpdateAction %s\n", err.Error())
 	}
 	if wait {
@@ -166,7 +167,7 @@ func reprotectAtCluster(configFolder, inputCluster, rgName string, verbose bool,
 		log.Fatal("Aborted. One of your RGs is in error state. Please verify RGs logs/events and try again.")
 	}
 	rg.Spec.Action = config.ActionReprotect
-	if err := cluster.UpdateReplicationGroup(context.Background(), rg); err != nil {
+	if err := cluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureReprotect), rg); err != nil {
 		log.Fatalf("reprotect: error executing UpdateAction %s\n", err.Error())
 	}
 	if wait {
@@ -17,6 +17,7 @@ package cmd
 import (
 	"context"
 
+	"github.com/dell/csm-replication/pkg/connection"
 	"github.com/dell/repctl/pkg/config"
 	"github.com/dell/repctl/pkg/k8s"
 	log "github.com/sirupsen/logrus"
@@ -146,7 +147,7 @@ func createSnapshot(configFolder, rgName, prefix, snNamespace, snClass string, v
 	rg.Annotations[prefix+"/snapshotNamespace"] = namespace
 	rg.Annotations[prefix+"/snapshotClass"] = snClass
 
-	if err := cluster.UpdateReplicationGroup(context.Background(), rg); err != nil {
+	if err := cluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureSnapshotReplication), rg); err != nil {
 		log.Fatalf("snapshot: error executing UpdateAction %s\n", err.Error())
 		return
 	}
@@ -17,6 +17,7 @@ package cmd
 import (
 	"context"
 
+	"github.com/dell/csm-replication/pkg/connection"
 	"github.com/dell/repctl/pkg/k8s"
 
 	"github.com/dell/repctl/pkg/config"
@@ -89,7 +90,7 @@ func swapAtRG(configFolder string, rgName string, verbose bool, wait bool) {
 		log.Fatal("Aborted. One of your RGs is in error state. Please verify RGs logs/events and try again.")
 	}
 	rg.Spec.Action = config.ActionSwap
-	if err := cluster.UpdateReplicationGroup(context.Background(), rg); err != nil {
+	if err := cluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureSwap), rg); err != nil {
 		log.Fatalf("swap: error executing UpdateAction %s", err.Error())
 	}
 	if wait {
@@ -129,7 +130,7 @@ func swapAtCluster(configFolder string, inputCluster string, rgName string, verb
 		log.Fatal("Aborted. One of your RGs is in error state. Please verify RGs logs/events and try again.")
 	}
 	rg.Spec.Action = config.ActionSwap
-	if err := cluster.UpdateReplicationGroup(context.Background(), rg); err != nil {
+	if err := cluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureSwap), rg); err != nil {
 		log.Fatalf("swap: error executing UpdateAction %s", err.Error())
 	}
 	if wait {
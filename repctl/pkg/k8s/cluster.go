@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	repv1 "github.com/dell/csm-replication/api/v1"
+	"github.com/dell/csm-replication/pkg/connection"
 	"github.com/dell/repctl/pkg/display"
 	"github.com/dell/repctl/pkg/metadata"
 	"github.com/dell/repctl/pkg/types"
@@ -44,6 +45,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// fieldManager is the field manager of the writes made by repctl
+const fieldManager = "repctl"
+
 // Clusters represents all clusters currently managed by `repctl`
 type Clusters struct {
 	Clusters []ClusterInterface
@@ -162,12 +166,12 @@ func (c *Cluster) SetClient(client ClientInterface) {
 
 // PatchReplicationGroup patches replication group
 func (c *Cluster) PatchReplicationGroup(ctx context.Context, rg *repv1.DellCSIReplicationGroup, patch client.Patch) error {
-	return c.client.Patch(ctx, rg, patch)
+	return c.client.Patch(ctx, rg, patch, client.FieldOwner(connection.NamedFieldManager(ctx, fieldManager)))
 }
 
 // UpdateReplicationGroup updates replication group
 func (c *Cluster) UpdateReplicationGroup(ctx context.Context, rg *repv1.DellCSIReplicationGroup) error {
-	return c.client.Update(ctx, rg)
+	return c.client.Update(ctx, rg, client.FieldOwner(connection.NamedFieldManager(ctx, fieldManager)))
 }
 
 // GetPersistentVolume returns persistent volume object by querying cluster using persistent volume name
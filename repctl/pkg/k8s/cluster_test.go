@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	repv1 "github.com/dell/csm-replication/api/v1"
+	"github.com/dell/csm-replication/pkg/connection"
 	fake_client "github.com/dell/csm-replication/test/e2e-framework/fake-client"
 	"github.com/dell/repctl/pkg/k8s"
 	"github.com/dell/repctl/pkg/metadata"
@@ -32,6 +33,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 type ClusterTestSuite struct {
@@ -426,6 +429,31 @@ func (suite *ClusterTestSuite) TestListReplicationGroups() {
 	suite.Contains(rgList.Items, *rg2)
 }
 
+func (suite *ClusterTestSuite) TestUpdateReplicationGroupFieldManager() {
+	rg := &repv1.DellCSIReplicationGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-rg",
+		},
+	}
+
+	var fieldManagers []string
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(rg).WithInterceptorFuncs(interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			updateOpts := &client.UpdateOptions{}
+			updateOpts.ApplyOptions(opts)
+			fieldManagers = append(fieldManagers, updateOpts.FieldManager)
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+	suite.cluster.SetClient(fakeClient)
+
+	err := suite.cluster.UpdateReplicationGroup(connection.WithFeature(context.Background(), connection.FeatureFailover), rg)
+	suite.NoError(err)
+	err = suite.cluster.UpdateReplicationGroup(context.Background(), rg)
+	suite.NoError(err)
+	suite.Equal([]string{"repctl/failover", "repctl"}, fieldManagers)
+}
+
 func (suite *ClusterTestSuite) TestFilterReplicationGroups() {
 	labelsAndAnnotationsA := make(map[string]string)
 	labelsAndAnnotationsA[metadata.RemoteClusterID] = "cluster-A"
@@ -16,6 +16,7 @@ package k8s
 
 import (
 	repv1 "github.com/dell/csm-replication/api/v1"
+	"github.com/dell/csm-replication/pkg/connection"
 	apiExtensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -33,7 +34,7 @@ func getControllerRuntimeClient(kubeconfig string) (client.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	k8sClient, err := client.New(clientConfig, client.Options{Scheme: scheme})
+	k8sClient, err := client.New(connection.WithFeatureUserAgent(clientConfig), client.Options{Scheme: scheme})
 	if err != nil {
 		return nil, err
 	}
@@ -29,6 +29,7 @@ const (
 	resourceRequest        = "resourceRequest"
 	replicationEnabled     = "isReplicationEnabled"
 	remoteSCName           = "remoteStorageClassName"
+	repairRequested        = "repairRequested"
 )
 
 // Exports
@@ -43,6 +44,7 @@ var (
 	ResourceRequest        string
 	ReplicationEnabled     string
 	RemoteSCName           string
+	RepairRequested        string
 )
 
 // Init - initialize keys
@@ -57,4 +59,5 @@ func Init(prefix string) {
 	ReplicationEnabled = path.Join(prefix, replicationEnabled)
 	RemoteSCName = path.Join(prefix, remoteSCName)
 	RemoteReplicationGroup = path.Join(prefix, remoteReplicationGroup)
+	RepairRequested = path.Join(prefix, repairRequested)
 }
@@ -36,6 +36,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -131,6 +132,7 @@ func InitializeSchemes() {
 	if !schemesInitialized {
 		utilruntime.Must(corev1.AddToScheme(Scheme))
 		utilruntime.Must(repv1.AddToScheme(Scheme))
+		utilruntime.Must(batchv1.AddToScheme(Scheme))
 		schemesInitialized = true
 	}
 	// +kubebuilder:scaffold:scheme